@@ -134,13 +134,20 @@ func (app *App) RunContext(ctx context.Context, args []string) error {
 		}
 	}(ctx)
 
-	if err := app.App.RunContext(ctx, args); err != nil && !errors.IsContextCanceled(err) {
+	// the cancellation is not an error by itself, unless it has killed a command, which exits with the dedicated code
+	if err := app.App.RunContext(ctx, args); err != nil && (!errors.IsContextCanceled(err) || isProcessExecutionError(err)) {
 		return err
 	}
 
 	return nil
 }
 
+func isProcessExecutionError(err error) bool {
+	var processErr util.ProcessExecutionError
+
+	return errors.As(err, &processErr)
+}
+
 // TerragruntCommands returns the set of Terragrunt commands.
 func TerragruntCommands(opts *options.TerragruntOptions) cli.Commands {
 	cmds := cli.Commands{
@@ -342,6 +342,24 @@ func TestParseMultiStringArg(t *testing.T) {
 	}
 }
 
+func TestParsePathPrependArg(t *testing.T) {
+	t.Parallel()
+
+	relDir, err := filepath.Abs("wrappers")
+	require.NoError(t, err)
+
+	absDir, err := filepath.Abs(string(filepath.Separator) + "opt")
+	require.NoError(t, err)
+
+	flagName := doubleDashed(commands.TerragruntPathPrependFlagName)
+
+	actualOptions, err := runAppTest([]string{"plan", flagName, "wrappers", flagName, absDir}, options.NewTerragruntOptions())
+	require.NoError(t, err)
+
+	// the relative directories are resolved against the working directory of Terragrunt
+	assert.Equal(t, []string{relDir, absDir}, actualOptions.PathPrepend)
+}
+
 func TestParseMutliStringKeyValueArg(t *testing.T) {
 	t.Parallel()
 
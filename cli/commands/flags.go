@@ -3,6 +3,8 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/gruntwork-io/go-commons/collections"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
@@ -23,6 +25,9 @@ const (
 	TerragruntTFPathFlagName = "terragrunt-tfpath"
 	TerragruntTFPathEnvName  = "TERRAGRUNT_TFPATH"
 
+	TerragruntPathPrependFlagName = "terragrunt-path-prepend"
+	TerragruntPathPrependEnvName  = "TERRAGRUNT_PATH_PREPEND"
+
 	TerragruntNoAutoInitFlagName = "terragrunt-no-auto-init"
 	TerragruntNoAutoInitEnvName  = "TERRAGRUNT_NO_AUTO_INIT"
 
@@ -65,6 +70,9 @@ const (
 	TerragruntParallelismFlagName = "terragrunt-parallelism"
 	TerragruntParallelismEnvName  = "TERRAGRUNT_PARALLELISM"
 
+	TerragruntMaxOutputSizeFlagName = "terragrunt-max-output-size"
+	TerragruntMaxOutputSizeEnvName  = "TERRAGRUNT_MAX_OUTPUT_SIZE"
+
 	TerragruntDebugFlagName = "terragrunt-debug"
 	TerragruntDebugEnvName  = "TERRAGRUNT_DEBUG"
 
@@ -138,6 +146,9 @@ const (
 	TerragruntNoColorFlagName = "terragrunt-no-color"
 	TerragruntNoColorEnvName  = "TERRAGRUNT_NO_COLOR"
 
+	TerragruntNoUnicodeFlagName = "terragrunt-no-unicode"
+	TerragruntNoUnicodeEnvName  = "TERRAGRUNT_NO_UNICODE"
+
 	TerragruntShowLogAbsPathsFlagName = "terragrunt-log-show-abs-paths"
 	TerragruntShowLogAbsPathsEnvName  = "TERRAGRUNT_LOG_SHOW_ABS_PATHS"
 
@@ -206,6 +217,26 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.TerraformPath,
 			Usage:       "Path to the Terraform binary. Default is tofu (on PATH).",
 		},
+		&cli.SliceFlag[string]{
+			Name:        TerragruntPathPrependFlagName,
+			EnvVar:      TerragruntPathPrependEnvName,
+			EnvVarSep:   string(os.PathListSeparator),
+			Destination: &opts.PathPrepend,
+			Usage:       "Directories prepended to the PATH of the executed commands, so the wrapped binaries in them are found first.",
+			// the relative directories would be resolved against the directory of each command otherwise
+			Action: func(ctx *cli.Context, val []string) error {
+				for i, dir := range val {
+					absDir, err := filepath.Abs(dir)
+					if err != nil {
+						return errors.New(err)
+					}
+
+					opts.PathPrepend[i] = absDir
+				}
+
+				return nil
+			},
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntNoAutoInitFlagName,
 			EnvVar:      TerragruntNoAutoInitEnvName,
@@ -318,6 +349,12 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.Parallelism,
 			Usage:       "*-all commands parallelism set to at most N modules",
 		},
+		&cli.GenericFlag[int]{
+			Name:        TerragruntMaxOutputSizeFlagName,
+			EnvVar:      TerragruntMaxOutputSizeEnvName,
+			Destination: &opts.MaxOutputSize,
+			Usage:       "The max size in bytes of the stdout and stderr of each command kept in memory, the rest is truncated. By default, unlimited.",
+		},
 		&cli.GenericFlag[string]{
 			Name:        TerragruntExcludesFileFlagName,
 			EnvVar:      TerragruntExcludesFileEnvName,
@@ -398,6 +435,16 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 				return nil
 			},
 		},
+		&cli.BoolFlag{
+			Name:        TerragruntNoUnicodeFlagName,
+			EnvVar:      TerragruntNoUnicodeEnvName,
+			Destination: &opts.DisableLogUnicode,
+			Usage:       "If specified, Terragrunt logs won't contain any Unicode symbols, such as the ones of the bool-symbol log format option.",
+			Action: func(_ *cli.Context, _ bool) error {
+				opts.LogFormatter.DisableUnicode()
+				return nil
+			},
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntUsePartialParseConfigCacheFlagName,
 			EnvVar:      TerragruntUsePartialParseConfigCacheEnvName,
@@ -433,7 +480,7 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 				switch val {
 				case format.BareFormatName:
 					opts.ForwardTFStdout = true
-				case format.JSONFormatName:
+				case format.JSONFormatName, format.JSONObjectFormatName:
 					opts.JSONLogFormat = true
 				}
 
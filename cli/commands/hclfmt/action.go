@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -20,22 +19,32 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/mattn/go-zglob"
+	"github.com/pmezard/go-difflib/difflib"
 
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/util"
 )
 
+const (
+	// stdinPath is the file path that makes hclfmt read HCL from stdin and write the result to stdout.
+	stdinPath = "-"
+
+	// diffContextLines is the number of unchanged lines shown around each change, the same as `diff -u` does.
+	diffContextLines = 3
+)
+
 func Run(opts *options.TerragruntOptions) error {
 	workingDir := opts.WorkingDir
 	targetFile := opts.HclFile
 	stdIn := opts.HclFromStdin
 
-	if stdIn {
-		if targetFile != "" {
-			return errors.Errorf("both stdin and path flags are specified")
-		}
+	if stdIn && targetFile != "" && targetFile != stdinPath {
+		return errors.Errorf("both stdin and path flags are specified")
+	}
 
+	// as with `terraform fmt -`, the path `-` stands for stdin
+	if stdIn || targetFile == stdinPath {
 		return formatFromStdin(opts)
 	}
 
@@ -47,7 +56,16 @@ func Run(opts *options.TerragruntOptions) error {
 
 		opts.Logger.Debugf("Formatting hcl file at: %s.", targetFile)
 
-		return formatTgHCL(opts, targetFile)
+		unformatted, err := formatTgHCL(opts, targetFile)
+		if err != nil {
+			return err
+		}
+
+		if unformatted {
+			return errors.New(UnformattedFilesError{targetFile})
+		}
+
+		return nil
 	}
 
 	opts.Logger.Debugf("Formatting hcl files from the directory tree %s.", opts.WorkingDir)
@@ -57,6 +75,11 @@ func Run(opts *options.TerragruntOptions) error {
 		return err
 	}
 
+	excludedPaths, err := util.GlobCanonicalPath(workingDir, opts.HclExcludeGlobs...)
+	if err != nil {
+		return err
+	}
+
 	filteredTgHclFiles := []string{}
 
 	for _, fname := range tgHclFiles {
@@ -78,6 +101,15 @@ func Run(opts *options.TerragruntOptions) error {
 			}
 		}
 
+		// exclude patterns take precedence over the `**/*.hcl` include, a file is skipped if it or any of its
+		// parent directories matches one of them
+		for _, excludedPath := range excludedPaths {
+			if util.HasPathPrefix(fname, excludedPath) {
+				skipFile = true
+				break
+			}
+		}
+
 		if skipFile {
 			opts.Logger.Debugf("%s was ignored", fname)
 		} else {
@@ -87,13 +119,24 @@ func Run(opts *options.TerragruntOptions) error {
 
 	opts.Logger.Debugf("Found %d hcl files", len(filteredTgHclFiles))
 
-	var formatErrors *errors.MultiError
+	var (
+		formatErrors     *errors.MultiError
+		unformattedFiles UnformattedFilesError
+	)
 
 	for _, tgHclFile := range filteredTgHclFiles {
-		err := formatTgHCL(opts, tgHclFile)
+		unformatted, err := formatTgHCL(opts, tgHclFile)
 		if err != nil {
 			formatErrors = formatErrors.Append(err)
 		}
+
+		if unformatted {
+			unformattedFiles = append(unformattedFiles, tgHclFile)
+		}
+	}
+
+	if len(unformattedFiles) > 0 {
+		formatErrors = formatErrors.Append(errors.New(unformattedFiles))
 	}
 
 	return formatErrors.ErrorOrNil()
@@ -134,20 +177,21 @@ func formatFromStdin(opts *options.TerragruntOptions) error {
 }
 
 // formatTgHCL uses the hcl2 library to format the hcl file. This will attempt to parse the HCL file first to
-// ensure that there are no syntax errors, before attempting to format it.
-func formatTgHCL(opts *options.TerragruntOptions, tgHclFile string) error {
+// ensure that there are no syntax errors, before attempting to format it. In check mode the file is left untouched
+// and true is returned if it is not properly formatted.
+func formatTgHCL(opts *options.TerragruntOptions, tgHclFile string) (bool, error) {
 	opts.Logger.Debugf("Formatting %s", tgHclFile)
 
 	info, err := os.Stat(tgHclFile)
 	if err != nil {
 		opts.Logger.Errorf("Error retrieving file info of %s", tgHclFile)
-		return err
+		return false, err
 	}
 
 	contentsStr, err := util.ReadFileAsString(tgHclFile)
 	if err != nil {
 		opts.Logger.Errorf("Error reading %s", tgHclFile)
-		return err
+		return false, err
 	}
 
 	contents := []byte(contentsStr)
@@ -155,7 +199,7 @@ func formatTgHCL(opts *options.TerragruntOptions, tgHclFile string) error {
 	err = checkErrors(opts.Logger, opts.DisableLogColors, contents, tgHclFile)
 	if err != nil {
 		opts.Logger.Errorf("Error parsing %s", tgHclFile)
-		return err
+		return false, err
 	}
 
 	newContents := hclwrite.Format(contents)
@@ -163,29 +207,30 @@ func formatTgHCL(opts *options.TerragruntOptions, tgHclFile string) error {
 	fileUpdated := !bytes.Equal(newContents, contents)
 
 	if opts.Diff && fileUpdated {
-		diff, err := bytesDiff(opts, contents, newContents, tgHclFile)
+		diff, err := bytesDiff(contents, newContents, tgHclFile)
 		if err != nil {
 			opts.Logger.Errorf("Failed to generate diff for %s", tgHclFile)
-			return err
+			return false, err
 		}
 
 		_, err = fmt.Fprintf(opts.Writer, "%s\n", diff)
 		if err != nil {
 			opts.Logger.Errorf("Failed to print diff for %s", tgHclFile)
-			return err
+			return false, err
 		}
 	}
 
 	if opts.Check && fileUpdated {
-		return fmt.Errorf("invalid file format %s", tgHclFile)
+		opts.Logger.Infof("%s is not properly formatted", tgHclFile)
+		return true, nil
 	}
 
 	if fileUpdated {
 		opts.Logger.Infof("%s was updated", tgHclFile)
-		return os.WriteFile(tgHclFile, newContents, info.Mode())
+		return false, os.WriteFile(tgHclFile, newContents, info.Mode())
 	}
 
-	return nil
+	return false, nil
 }
 
 // checkErrors takes in the contents of a hcl file and looks for syntax errors.
@@ -208,52 +253,18 @@ func checkErrors(logger log.Logger, disableColor bool, contents []byte, tgHclFil
 	return nil
 }
 
-// bytesDiff uses GNU diff to display the differences between the contents of HCL file before and after formatting
-func bytesDiff(opts *options.TerragruntOptions, b1, b2 []byte, path string) ([]byte, error) {
-	f1, err := os.CreateTemp("", "")
+// bytesDiff generates a unified diff between the contents of HCL file before and after formatting.
+func bytesDiff(b1, b2 []byte, path string) ([]byte, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(b1)),
+		B:        difflib.SplitLines(string(b2)),
+		FromFile: filepath.Join("old", path),
+		ToFile:   filepath.Join("new", path),
+		Context:  diffContextLines,
+	})
 	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if err := f1.Close(); err != nil {
-			opts.Logger.Warnf("Failed to close file %s %v", f1.Name(), err)
-		}
-
-		if err := os.Remove(f1.Name()); err != nil {
-			opts.Logger.Warnf("Failed to remove file %s %v", f1.Name(), err)
-		}
-	}()
-
-	f2, err := os.CreateTemp("", "")
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if err := f2.Close(); err != nil {
-			opts.Logger.Warnf("Failed to close file %s %v", f2.Name(), err)
-		}
-
-		if err := os.Remove(f2.Name()); err != nil {
-			opts.Logger.Warnf("Failed to remove file %s %v", f2.Name(), err)
-		}
-	}()
-
-	if _, err := f1.Write(b1); err != nil {
-		return nil, err
-	}
-
-	if _, err := f2.Write(b2); err != nil {
-		return nil, err
-	}
-
-	data, err := exec.Command("diff", "--label="+filepath.Join("old", path), "--label="+filepath.Join("new/", path), "-u", f1.Name(), f2.Name()).CombinedOutput()
-	if len(data) > 0 {
-		// diff exits with a non-zero status when the files don't match.
-		// Ignore that failure as long as we get output.
-		err = nil
+		return nil, errors.New(err)
 	}
 
-	return data, err
+	return []byte(diff), nil
 }
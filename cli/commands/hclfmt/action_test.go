@@ -1,6 +1,7 @@
 package hclfmt_test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -94,6 +95,60 @@ func TestHCLFmt(t *testing.T) {
 
 }
 
+func TestHCLFmtExcludeGlobs(t *testing.T) {
+	t.Parallel()
+
+	tmpPath, err := files.CopyFolderToTemp("./testdata/fixtures", t.Name(), func(path string) bool { return true })
+
+	t.Cleanup(func() {
+		os.RemoveAll(tmpPath)
+	})
+
+	require.NoError(t, err)
+
+	expected, err := util.ReadFileAsString("./testdata/fixtures/expected.hcl")
+	require.NoError(t, err)
+
+	tgOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	tgOptions.WorkingDir = tmpPath
+	tgOptions.HclExcludeGlobs = []string{"**/.history/**", "a/b/c/d"}
+
+	err = hclfmt.Run(tgOptions)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		path     string
+		excluded bool
+	}{
+		{path: "terragrunt.hcl"},
+		{path: "a/terragrunt.hcl"},
+		{path: "a/b/c/terragrunt.hcl"},
+		{path: "a/b/c/d/services.hcl", excluded: true},
+		{path: "a/b/c/d/e/terragrunt.hcl", excluded: true},
+		{path: "ignored/.history/terragrunt.hcl", excluded: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := util.ReadFileAsString(filepath.Join(tmpPath, tc.path))
+			require.NoError(t, err)
+
+			if !tc.excluded {
+				assert.Equal(t, expected, actual)
+				return
+			}
+
+			original, err := util.ReadFileAsString(filepath.Join("./testdata/fixtures", tc.path))
+			require.NoError(t, err)
+			assert.Equal(t, original, actual)
+		})
+	}
+}
+
 func TestHCLFmtErrors(t *testing.T) {
 	t.Parallel()
 
@@ -207,6 +262,16 @@ func TestHCLFmtCheckErrors(t *testing.T) {
 		"a/b/c/d/e/terragrunt.hcl",
 	}
 
+	var unformattedErr hclfmt.UnformattedFilesError
+	require.ErrorAs(t, err, &unformattedErr)
+
+	expectedUnformatted := []string{filepath.ToSlash(filepath.Join(tmpPath, "expected.hcl"))}
+	for _, dir := range dirs {
+		expectedUnformatted = append(expectedUnformatted, filepath.ToSlash(filepath.Join(tmpPath, dir)))
+	}
+
+	assert.ElementsMatch(t, expectedUnformatted, []string(unformattedErr))
+
 	for _, dir := range dirs {
 		// Capture range variable into for block so it doesn't change while looping
 		dir := dir
@@ -222,6 +287,40 @@ func TestHCLFmtCheckErrors(t *testing.T) {
 	}
 }
 
+func TestHCLFmtDiff(t *testing.T) {
+	t.Parallel()
+
+	tmpPath, err := files.CopyFolderToTemp("../../../test/fixtures/hclfmt-check-errors", t.Name(), func(path string) bool { return true })
+
+	t.Cleanup(func() {
+		os.RemoveAll(tmpPath)
+	})
+
+	require.NoError(t, err)
+
+	tgOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+
+	tgOptions.Check = true
+	tgOptions.Diff = true
+	tgOptions.Writer = &stdout
+	tgOptions.HclFile = "terragrunt.hcl"
+	tgOptions.WorkingDir = tmpPath
+
+	err = hclfmt.Run(tgOptions)
+	require.Error(t, err)
+
+	tgHclPath := filepath.Join(tmpPath, "terragrunt.hcl")
+	output := stdout.String()
+
+	assert.Contains(t, output, "--- "+filepath.Join("old", tgHclPath)+"\n")
+	assert.Contains(t, output, "+++ "+filepath.Join("new", tgHclPath)+"\n")
+	assert.Contains(t, output, "-  bar=\"baz\"\n")
+	assert.Contains(t, output, "+  bar = \"baz\"\n")
+}
+
 func TestHCLFmtFile(t *testing.T) {
 	t.Parallel()
 
@@ -282,36 +381,54 @@ func TestHCLFmtFile(t *testing.T) {
 func TestHCLFmtStdin(t *testing.T) {
 	t.Parallel()
 
+	expected, err := os.ReadFile("../../../test/fixtures/hclfmt-stdin/expected.hcl")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		hclFromStdin bool
+		hclFile      string
+	}{
+		{name: "stdin flag", hclFromStdin: true},
+		{name: "dash path", hclFile: "-"},
+	}
+
 	realStdin := os.Stdin
 	realStdout := os.Stdout
 
-	tempStdoutFile, err := os.CreateTemp(t.TempDir(), "stdout.hcl")
 	defer func() {
-		_ = tempStdoutFile.Close()
+		os.Stdin = realStdin
+		os.Stdout = realStdout
 	}()
-	require.NoError(t, err)
 
-	os.Stdout = tempStdoutFile
-	defer func() { os.Stdout = realStdout }()
+	// The subtests replace the global stdin and stdout, so they must not run in parallel.
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) { //nolint:paralleltest
+			tempStdoutFile, err := os.CreateTemp(t.TempDir(), "stdout.hcl")
+			defer func() {
+				_ = tempStdoutFile.Close()
+			}()
+			require.NoError(t, err)
 
-	os.Stdin, err = os.Open("../../../test/fixtures/hclfmt-stdin/terragrunt.hcl")
-	defer func() { os.Stdin = realStdin }()
-	require.NoError(t, err)
+			os.Stdout = tempStdoutFile
 
-	expected, err := os.ReadFile("../../../test/fixtures/hclfmt-stdin/expected.hcl")
-	require.NoError(t, err)
+			os.Stdin, err = os.Open("../../../test/fixtures/hclfmt-stdin/terragrunt.hcl")
+			require.NoError(t, err)
 
-	tgOptions, err := options.NewTerragruntOptionsForTest("")
-	require.NoError(t, err)
+			tgOptions, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
 
-	// format hcl from stdin
-	tgOptions.HclFromStdin = true
-	err = hclfmt.Run(tgOptions)
-	require.NoError(t, err)
+			// format hcl from stdin
+			tgOptions.HclFromStdin = tc.hclFromStdin
+			tgOptions.HclFile = tc.hclFile
+			err = hclfmt.Run(tgOptions)
+			require.NoError(t, err)
 
-	formatted, err := os.ReadFile(tempStdoutFile.Name())
-	require.NoError(t, err)
-	assert.Equal(t, expected, formatted)
+			formatted, err := os.ReadFile(tempStdoutFile.Name())
+			require.NoError(t, err)
+			assert.Equal(t, expected, formatted)
+		})
+	}
 }
 
 func TestHCLFmtHeredoc(t *testing.T) {
@@ -11,6 +11,7 @@ const (
 
 	FlagNameTerragruntHCLFmt           = "terragrunt-hclfmt-file"
 	FlagNameTerragruntHCLFmtExcludeDir = "terragrunt-hclfmt-exclude-dir"
+	FlagNameTerragruntHCLFmtExclude    = "terragrunt-hclfmt-exclude"
 	FlagNameTerragruntCheck            = "terragrunt-check"
 	FlagNameTerragruntDiff             = "terragrunt-diff"
 	FlagNameTerragruntHCLFmtStdin      = "terragrunt-hclfmt-stdin"
@@ -21,7 +22,7 @@ func NewFlags(opts *options.TerragruntOptions) cli.Flags {
 		&cli.GenericFlag[string]{
 			Name:        FlagNameTerragruntHCLFmt,
 			Destination: &opts.HclFile,
-			Usage:       "The path to a single hcl file that the hclfmt command should run on.",
+			Usage:       "The path to a single hcl file that the hclfmt command should run on, or - to read from stdin.",
 		},
 		&cli.SliceFlag[string]{
 			Name:        FlagNameTerragruntHCLFmtExcludeDir,
@@ -29,6 +30,12 @@ func NewFlags(opts *options.TerragruntOptions) cli.Flags {
 			EnvVar:      "TERRAGRUNT_HCLFMT_EXCLUDE_DIR",
 			Usage:       "Skip HCL formatting in given directories.",
 		},
+		&cli.SliceFlag[string]{
+			Name:        FlagNameTerragruntHCLFmtExclude,
+			Destination: &opts.HclExcludeGlobs,
+			EnvVar:      "TERRAGRUNT_HCLFMT_EXCLUDE",
+			Usage:       "Skip HCL formatting of files matching given glob patterns, relative to the working directory.",
+		},
 		&cli.BoolFlag{
 			Name:        FlagNameTerragruntCheck,
 			Destination: &opts.Check,
@@ -0,0 +1,13 @@
+package hclfmt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnformattedFilesError is returned in check mode when some of the files are not properly formatted.
+type UnformattedFilesError []string
+
+func (err UnformattedFilesError) Error() string {
+	return fmt.Sprintf("The following files are not properly formatted: %s", strings.Join(err, ", "))
+}
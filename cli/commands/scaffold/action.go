@@ -1,14 +1,26 @@
 package scaffold
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
+	"path"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
 	"github.com/gruntwork-io/terragrunt/shell"
 
 	"github.com/gruntwork-io/terragrunt/terraform"
@@ -16,13 +28,19 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands/hclfmt"
 	"github.com/gruntwork-io/terragrunt/util"
 
+	"github.com/google/shlex"
 	boilerplate_options "github.com/gruntwork-io/boilerplate/options"
 	"github.com/gruntwork-io/boilerplate/templates"
 	"github.com/gruntwork-io/boilerplate/variables"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/pkg/log/writer"
 	"github.com/gruntwork-io/terratest/modules/files"
 	"github.com/hashicorp/go-getter/v2"
+	"github.com/hashicorp/go-version"
+	"github.com/mitchellh/go-wordwrap"
+	"golang.org/x/text/unicode/norm"
 )
 
 const (
@@ -30,24 +48,118 @@ const (
 	sourceURLTypeGit   = "git-ssh"
 	sourceGitSSHUser   = "git"
 
-	sourceURLTypeVar    = "SourceUrlType"
-	sourceGitSSHUserVar = "SourceGitSshUser"
-	refVar              = "Ref"
+	sourceURLTypeVar         = "SourceUrlType"
+	sourceGitSSHUserVar      = "SourceGitSshUser"
+	sourceGitSSHHostsVar     = "SourceGitSshHosts"
+	sourceGitSSHPortVar      = "SourceGitSshPort"
+	sourceGitSSHHostAliasVar = "SourceGitSshHostAlias"
+	refVar                   = "Ref"
+	allowPrereleaseVar       = "AllowPrerelease"
+	tagPrefixVar             = "TagPrefix"
+	descriptionWrapWidthVar  = "DescriptionWrapWidth"
+	normalizeDescriptionsVar = "NormalizeDescriptions"
+	staleRefDaysVar          = "StaleRefDays"
+	generateDependenciesVar  = "GenerateDependencies"
+	enableRootIncludeVar     = "EnableRootInclude"
+	placeholdersVar          = "placeholders"
+
+	// gitSSHHostUserKey, gitSSHHostPortKey, gitSSHHostPathStyleKey and gitSSHHostAliasKey are the keys of a `SourceGitSshHosts` host entry.
+	gitSSHHostUserKey      = "User"
+	gitSSHHostPortKey      = "Port"
+	gitSSHHostPathStyleKey = "PathStyle"
+	gitSSHHostAliasKey     = "HostAlias"
+
+	maxPortNumber = 65535
+
+	hoursPerDay = 24
+
+	// gitPathStyleDefault keeps the repository path as is, e.g. github.com/team/repo.
+	gitPathStyleDefault = "default"
+	// gitPathStyleNested is used by hosts that allow nested groups, e.g. gitlab.com/group/subgroup/repo.
+	gitPathStyleNested = "nested"
 	// refParam - ?ref param from url
 	refParam = "ref"
+	// versionParam - ?version param of the registry urls
+	versionParam = "version"
+	// registryScheme is the scheme of the module registry urls, e.g. tfr://registry.terraform.io/org/name/aws?version=1.0.0
+	registryScheme = "tfr"
 
 	moduleURLPattern = `(?:git|hg|s3|gcs)::([^:]+)://([^/]+)(/.*)`
 	moduleURLParts   = 4
 
+	gitPrefix = "git::"
+
+	// authorizationHeader is the header set to the value of `--terragrunt-scaffold-auth-header`.
+	authorizationHeader = "Authorization"
+	// redactedUserInfo replaces credentials in the logged urls.
+	redactedUserInfo = "***"
+
+	// scaffoldVarEnvPrefix is the prefix of environment variables which are passed as scaffold variables.
+	scaffoldVarEnvPrefix = "TERRAGRUNT_SCAFFOLD_VAR_"
+
 	DefaultBoilerplateConfig = `
 variables:
   - name: EnableRootInclude
     description: Should include root module
     type: bool
     default: true
+  - name: GenerateBackend
+    description: Should generate remote state backend configuration
+    type: bool
+    default: false
+  - name: GenerateExampleVars
+    description: Should generate an example file with values of optional variables
+    type: bool
+    default: false
+  - name: GenerateDependencies
+    description: Should generate a commented out dependency block with mock outputs of the module
+    type: bool
+    default: false
+  - name: GenerateProvidersSummary
+    description: Should generate a comment with the providers required by the module
+    type: bool
+    default: false
+  - name: GenerateVersionFile
+    description: Should generate a .terraform-version file with the minimum version required by the module
+    type: bool
+    default: false
+  - name: GenerateGitignore
+    description: Should generate a .gitignore file excluding the Terragrunt and Terraform local directories
+    type: bool
+    default: false
+  - name: GenerateReadme
+    description: Should generate a README.md file with the description of the module taken from its README
+    type: bool
+    default: false
+skip_files:
+  - path: "` + DefaultTfvarsExampleFile + `"
+    if: "{{ not .GenerateExampleVars }}"
+  - path: "` + DefaultTerraformVersionFile + `"
+    if: "{{ or (not .GenerateVersionFile) (not .minimumRequiredVersion) }}"
+  - path: "` + DefaultGitignoreFile + `"
+    if: "{{ not .GenerateGitignore }}"
+  - path: "` + DefaultReadmeFile + `"
+    if: "{{ or (not .GenerateReadme) (not .moduleDescription) }}"
 `
 	DefaultTerragruntTemplate = `
 # This is a Terragrunt module generated by boilerplate.
+{{- if and (hasKey $ "moduleDeprecation") .moduleDeprecation }}
+#
+# DEPRECATED: the module is deprecated.{{ if .moduleDeprecation.Reason }} {{ .moduleDeprecation.Reason | replaceAll "\n" " " }}{{ end }}
+{{- if .moduleDeprecation.Replacement }}
+# Use {{ .moduleDeprecation.Replacement }} instead.
+{{- end }}
+{{- if .moduleDeprecation.Link }}
+# See {{ .moduleDeprecation.Link }}
+{{- end }}
+{{- end }}
+{{- if and .GenerateProvidersSummary .requiredProviders }}
+#
+# Providers required by the module:
+{{- range .requiredProviders }}
+#   {{ .Name }}{{ if .Source }} ({{ .Source }}){{ end }}{{ if .Version }} {{ .Version }}{{ end }}
+{{- end }}
+{{- end }}
 terraform {
   source = "{{ .sourceUrl }}"
 }
@@ -56,6 +168,35 @@ include "root" {
   path = find_in_parent_folders()
 }
 {{ end }}
+{{- if .GenerateBackend }}
+remote_state {
+  backend = "s3"
+  generate = {
+    path      = "backend.tf"
+    if_exists = "overwrite_terragrunt"
+  }
+  config = {
+    bucket  = "{{ .modulePath | replaceAll "/" "-" }}-terraform-state" # TODO: fill in value
+    key     = "{{ .modulePath }}/terraform.tfstate"
+    region  = "us-east-1" # TODO: fill in value
+    encrypt = true
+  }
+}
+{{ end }}
+{{- if and .GenerateDependencies .outputs }}
+# ----------------------------------------------------------------------------------------------------------------------
+# Dependency block for the modules using the outputs of this module
+# ----------------------------------------------------------------------------------------------------------------------
+# dependency "{{ .modulePath | base }}" {
+#   config_path = "../{{ .modulePath | base }}"  # TODO: fill in value
+#
+#   mock_outputs = {
+{{- range .outputs }}
+#     {{ .Name }} = ""{{ if .Description }}  # {{ .Description | replaceAll "\n" " " }}{{ end }}
+{{- end }}
+#   }
+# }
+{{ end }}
 inputs = {
   # --------------------------------------------------------------------------------------------------------------------
   # Required input variables
@@ -70,7 +211,14 @@ inputs = {
     {{- end }}
   {{- end }}
   # Type: {{ .Type }}
+  {{- if .Sensitive }}
+  # SENSITIVE
+  # {{ .Name }} = {{ .DefaultValuePlaceholder }}  # TODO: set via environment, do not commit
+  {{- else if and (hasKey $ "placeholders") (index $.placeholders .Name) }}
+  {{ .Name }} = {{ index $.placeholders .Name }}
+  {{- else }}
   {{ .Name }} = {{ .DefaultValuePlaceholder }}  # TODO: fill in value
+  {{- end }}
   {{ end }}
 
   # --------------------------------------------------------------------------------------------------------------------
@@ -87,19 +235,124 @@ inputs = {
     {{- end }}
   {{- end }}
   # Type: {{ .Type }}
-  # {{ .Name }} = {{ .DefaultValue }}
+  {{- if .Sensitive }}
+  # SENSITIVE
+  {{- end }}
+  # {{ .Name }} = {{ .DefaultValue | replaceAll "\n" "\n  # " }}
   {{ end }}
 }
 `
+	// DefaultBoilerplateConfigFile is the only config file name read by boilerplate.
+	DefaultBoilerplateConfigFile = "boilerplate.yml"
+	// DefaultTerragruntTemplateFile is the name of the file generated from the default template.
+	DefaultTerragruntTemplateFile = "terragrunt.hcl"
+
+	// hclFileExt is the extension of the files formatted after the generation.
+	hclFileExt = ".hcl"
+
+	// stdinTemplateURL is the template url that makes scaffold read a single file template from stdin.
+	stdinTemplateURL = "-"
+	// stdinBoilerplateConfig is the boilerplate config of the template read from stdin.
+	stdinBoilerplateConfig = "variables: []\n"
+
+	DefaultTfvarsExampleFile     = "inputs.auto.tfvars.example"
+	DefaultTfvarsExampleTemplate = `
+# This is an example of the optional input variables generated by boilerplate.
+# Copy or rename this file and adjust the values you wish to set.
+{{ range .optionalVariables }}
+{{- if eq 1 (regexSplit "\n" .Description -1 | len ) }}
+# Description: {{ .Description }}
+{{- else }}
+# Description:
+  {{- range $line := regexSplit "\n" .Description -1 }}
+#   {{ $line }}
+  {{- end }}
+{{- end }}
+# Type: {{ .Type }}
+{{- if .Sensitive }}
+# SENSITIVE
+{{- end }}
+{{ .Name }} = {{ .DefaultValue }}
+{{ end }}
+`
+
+	// DefaultTerraformVersionFile is read by version managers, such as tfenv, to select the terraform version.
+	DefaultTerraformVersionFile = ".terraform-version"
+
+	// StageManifestFile is written to the stage dir with the module the staged files were generated from.
+	StageManifestFile = ".terragrunt-scaffold-manifest.json"
+
+	// StackManifestFile is declared by the templates generating a stack of units, each rendered from its own subdirectory.
+	StackManifestFile = "stack.yml"
+
+	DefaultTerraformVersionTemplate = `{{ .minimumRequiredVersion }}
+`
+
+	// DefaultReadmeFile is both the README of the module the description is taken from and the generated README of the unit.
+	DefaultReadmeFile = "README.md"
+
+	DefaultReadmeTemplate = `# {{ .modulePath | base }}
+
+{{ .moduleDescription }}
+
+This unit is generated by boilerplate from the module {{ .sourceUrl }}
+{{- if and (hasKey $ "moduleRef") .moduleRef }} pinned to {{ .moduleRef }}{{ end }}.
+See the README of the module for the details of its inputs and outputs.
+`
+
+	// DefaultGitignoreFile excludes the local directories of Terragrunt and Terraform from the repository of the unit.
+	DefaultGitignoreFile = ".gitignore"
+
+	DefaultGitignoreTemplate = `# Terragrunt and Terraform local directories generated by boilerplate.
+.terragrunt-cache/
+.terraform/
+
+# Crash logs and the plan files may contain sensitive data.
+crash.log
+crash.*.log
+*.tfplan
+
+# .terraform.lock.hcl is not ignored, commit it to pin the provider versions.
+`
+)
+
+var (
+	moduleURLRegex   = regexp.MustCompile(moduleURLPattern)
+	httpsSchemeRegex = regexp.MustCompile(`(?i)^https://`)
+	commitSHARegex   = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+	// versionLowerBoundRegex matches the version constraints which are satisfied by the version itself, e.g. `>= 1.5`.
+	versionLowerBoundRegex = regexp.MustCompile(`^(?:>=|~>|=)?\s*(v?[0-9][^\s]*)$`)
+
+	// httpUserInfoRegex matches the user info of HTTP(S) urls, e.g. a token in https://<token>@github.com/org/repo.
+	httpUserInfoRegex = regexp.MustCompile(`(?i)(https?://)[^/?#@]+@`)
+	// userInfoPasswordRegex matches the password in the user info of urls, e.g. ssh://git:<password>@host/repo.
+	userInfoPasswordRegex = regexp.MustCompile(`(://[^/?#@:]+):[^/?#@]+@`)
 )
 
-var moduleURLRegex = regexp.MustCompile(moduleURLPattern)
+// supportedSourceSchemes are the url schemes and the go-getter forced getters allowed in module urls.
+var supportedSourceSchemes = []string{"git", "hg", "http", "https", "ssh", "s3", "gcs", "file", "smb", "codecommit", registryScheme} //nolint:gochecknoglobals
+
+// defaultGitSSHHosts contains the Git/SSH rewrite settings of well-known git hosting services.
+var defaultGitSSHHosts = map[string]gitSSHHost{ //nolint:gochecknoglobals
+	"github.com":    {user: sourceGitSSHUser, pathStyle: gitPathStyleDefault},
+	"gitlab.com":    {user: sourceGitSSHUser, pathStyle: gitPathStyleNested},
+	"bitbucket.org": {user: sourceGitSSHUser, pathStyle: gitPathStyleDefault},
+}
 
 func Run(ctx context.Context, opts *options.TerragruntOptions, moduleURL, templateURL string) error {
 	// download remote repo to local
 	var dirsToClean []string
 	// clean all temp dirs
 	defer func() {
+		if opts.ScaffoldKeepTemp {
+			for _, dir := range dirsToClean {
+				opts.Logger.Infof("Keeping temporary directory %s", dir)
+			}
+
+			return
+		}
+
 		for _, dir := range dirsToClean {
 			if err := os.RemoveAll(dir); err != nil {
 				opts.Logger.Warnf("Failed to clean up dir %s: %v", dir, err)
@@ -107,8 +360,8 @@ func Run(ctx context.Context, opts *options.TerragruntOptions, moduleURL, templa
 		}
 	}()
 
-	// scaffold only in empty directories
-	if empty, err := util.IsDirectoryEmpty(opts.WorkingDir); !empty || err != nil {
+	// scaffold only in empty directories, the verified directory contains the scaffolded files
+	if empty, err := util.IsDirectoryEmpty(opts.WorkingDir); (!empty && !opts.ScaffoldVerify) || err != nil {
 		if err != nil {
 			return err
 		}
@@ -116,10 +369,53 @@ func Run(ctx context.Context, opts *options.TerragruntOptions, moduleURL, templa
 		opts.Logger.Warnf("The working directory %s is not empty.", opts.WorkingDir)
 	}
 
+	if opts.ScaffoldApplyFrom != "" {
+		if opts.ScaffoldVerify {
+			return errors.New(ConflictingFlagsError{FlagNameTerragruntScaffoldVerify, FlagNameTerragruntScaffoldApplyFrom})
+		}
+
+		return applyStagedFiles(ctx, opts, moduleURL)
+	}
+
+	// the include dir is resolved once, since the matrix values and the stack units are scaffolded with their own working dirs
+	if err := resolveIncludeDir(opts); err != nil {
+		return err
+	}
+
 	if moduleURL == "" {
 		return errors.New(NoModuleURLPassed{})
 	}
 
+	if opts.ScaffoldTemplateFromStdin {
+		if templateURL != "" && templateURL != stdinTemplateURL {
+			return errors.New(StdinTemplateConflictError(templateURL))
+		}
+
+		templateURL = stdinTemplateURL
+	}
+
+	// fail before downloading the module if the built-in template does not exist
+	if _, err := templatePresetFiles(opts.ScaffoldTemplatePreset); err != nil {
+		return err
+	}
+
+	matrixName, matrixValues, err := parseMatrix(opts.ScaffoldMatrix)
+	if err != nil {
+		return err
+	}
+
+	if matrixName != "" && opts.ScaffoldStageDir != "" {
+		return errors.New(ConflictingFlagsError{FlagNameTerragruntScaffoldMatrix, FlagNameTerragruntScaffoldStageDir})
+	}
+
+	// the module url as passed is recorded in the stage manifest, so that it can be compared when the files are applied
+	passedModuleURL := moduleURL
+
+	// the module, the template and the var files are downloaded to temporary directories
+	if err := util.CheckTempDirWritable(); err != nil {
+		return err
+	}
+
 	// create temporary directory where to download module
 	tempDir, err := os.MkdirTemp("", "scaffold")
 	if err != nil {
@@ -128,8 +424,16 @@ func Run(ctx context.Context, opts *options.TerragruntOptions, moduleURL, templa
 
 	dirsToClean = append(dirsToClean, tempDir)
 
+	// download remote var files
+	varFiles, varFilesDirs, err := prepareVarFiles(ctx, opts)
+	dirsToClean = append(dirsToClean, varFilesDirs...)
+
+	if err != nil {
+		return errors.New(err)
+	}
+
 	// prepare variables
-	vars, err := variables.ParseVars(opts.ScaffoldVars, opts.ScaffoldVarFiles)
+	vars, err := parseScaffoldVars(opts, varFiles)
 	if err != nil {
 		return errors.New(err)
 	}
@@ -140,221 +444,2202 @@ func Run(ctx context.Context, opts *options.TerragruntOptions, moduleURL, templa
 		return errors.New(err)
 	}
 
-	opts.Logger.Infof("Scaffolding a new Terragrunt module %s to %s", moduleURL, opts.WorkingDir)
+	if err := validateSourceURLScheme(moduleURL); err != nil {
+		return err
+	}
+
+	opts.Logger.Infof("Scaffolding a new Terragrunt module %s to %s", redactSourceURL(moduleURL), opts.WorkingDir)
+
+	if configFile := configFileName(opts); filepath.Ext(configFile) != hclFileExt {
+		opts.Logger.Warnf("The generated config file %s does not have the %s extension, so it will not be formatted.", configFile, hclFileExt)
+	}
+
+	// the registry modules are downloaded from the source returned by the registry, while the registry url is generated
+	moduleURL, downloadURL, deprecation, err := resolveRegistryModule(ctx, opts, moduleURL)
+	if err != nil {
+		return err
+	}
+
+	if templateURL, err = resolveTemplateURL(ctx, opts, vars, moduleURL, templateURL, tempDir); err != nil {
+		return err
+	}
+
+	// the lock is resolved before the module variables are added, so it records only the passed inputs
+	lock := newScaffoldLock(ctx, opts, vars, moduleURL, templateURL)
+
+	if opts.ScaffoldVerify {
+		return verifyScaffoldLock(opts, lock)
+	}
 
-	if _, err := getter.GetAny(ctx, tempDir, moduleURL); err != nil {
+	if _, err := getAny(ctx, opts, tempDir, downloadURL); err != nil {
 		return errors.New(err)
 	}
 
+	if err := checkModuleDeprecation(opts, moduleURL, deprecation); err != nil {
+		return err
+	}
+
 	// extract variables from downloaded module
-	requiredVariables, optionalVariables, err := parseVariables(opts, tempDir)
+	requiredVariables, optionalVariables, err := parseVariables(opts, vars, tempDir)
 	if err != nil {
 		return errors.New(err)
 	}
 
 	opts.Logger.Debugf("Parsed %d required variables and %d optional variables", len(requiredVariables), len(optionalVariables))
 
-	// prepare boilerplate files to render Terragrunt files
-	boilerplateDir, err := prepareBoilerplateFiles(ctx, opts, templateURL, tempDir)
+	requiredProviders, err := config.ParseRequiredProviders(opts, tempDir)
 	if err != nil {
 		return errors.New(err)
 	}
 
-	// add additional variables
-	vars["requiredVariables"] = requiredVariables
-	vars["optionalVariables"] = optionalVariables
-
-	vars["sourceUrl"] = moduleURL
+	requiredVersion, err := config.ParseRequiredVersion(opts, tempDir)
+	if err != nil {
+		return errors.New(err)
+	}
 
-	opts.Logger.Infof("Running boilerplate generation to %s", opts.WorkingDir)
-	boilerplateOpts := &boilerplate_options.BoilerplateOptions{
-		OutputFolder:    opts.WorkingDir,
-		OnMissingKey:    boilerplate_options.DefaultMissingKeyAction,
-		OnMissingConfig: boilerplate_options.DefaultMissingConfigAction,
-		Vars:            vars,
-		DisableShell:    true,
-		DisableHooks:    true,
-		NonInteractive:  opts.NonInteractive,
-		TemplateFolder:  boilerplateDir,
+	moduleDescription, err := readModuleDescription(tempDir)
+	if err != nil {
+		return err
 	}
 
-	emptyDep := variables.Dependency{}
-	if err := templates.ProcessTemplate(boilerplateOpts, boilerplateOpts, emptyDep); err != nil {
-		return errors.New(err)
+	generateDependencies, err := boolVar(vars, generateDependenciesVar)
+	if err != nil {
+		return err
 	}
 
-	opts.Logger.Infof("Running fmt on generated code %s", opts.WorkingDir)
+	// outputs are parsed only on demand, since they are used only to generate the dependency block
+	var outputs []*config.ParsedOutput
 
-	if err := hclfmt.Run(opts); err != nil {
+	if generateDependencies {
+		if outputs, err = config.ParseOutputs(opts, tempDir); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	// prepare boilerplate files to render Terragrunt files
+	boilerplateDir, err := prepareBoilerplateFiles(ctx, opts, templateURL, tempDir)
+	if err != nil {
 		return errors.New(err)
 	}
 
-	opts.Logger.Info("Scaffolding completed")
+	// the template and the default boilerplate files are stored in separate temporary directories,
+	// while a local template dir used as is must be kept
+	localDir, isLocalTemplate := localTemplateDir(opts, templateURL)
+	if !util.HasPathPrefix(boilerplateDir, tempDir) && (!isLocalTemplate || boilerplateDir != localDir) {
+		dirsToClean = append(dirsToClean, boilerplateDir)
+	}
 
-	return nil
-}
+	// add additional variables
+	vars["requiredVariables"] = requiredVariables
+	vars["optionalVariables"] = optionalVariables
+	vars["requiredProviders"] = requiredProviders
+	vars["requiredVersion"] = requiredVersion
+	vars["minimumRequiredVersion"] = minimumRequiredVersion(requiredVersion)
+	vars["moduleDescription"] = moduleDescription
+	vars["moduleDeprecation"] = deprecation
+	vars["outputs"] = outputs
+
+	vars["sourceUrl"] = templateSourceURL(opts, moduleURL)
+	vars["modulePath"] = modulePath(opts, moduleURL)
+	vars["configFile"] = configFileName(opts)
+
+	for name, value := range moduleMetadata(opts, moduleURL) {
+		vars[name] = value
+	}
 
-// prepareBoilerplateFiles prepares boilerplate files.
-func prepareBoilerplateFiles(ctx context.Context, opts *options.TerragruntOptions, templateURL string, tempDir string) (string, error) {
-	// identify template url
-	templateDir := ""
+	if vars[placeholdersVar], err = placeholders(opts, vars); err != nil {
+		return err
+	}
 
-	if templateURL != "" {
-		// process template url if was passed
-		parsedTemplateURL, err := terraform.ToSourceURL(templateURL, tempDir)
-		if err != nil {
-			return "", errors.New(err)
+	stack, err := readStackManifest(boilerplateDir)
+	if err != nil {
+		return err
+	}
+
+	if stack != nil {
+		// the flags are checked in order, so the same flag is reported if both are set
+		for _, flag := range []struct {
+			name  string
+			isSet bool
+		}{
+			{FlagNameTerragruntScaffoldMatrix, matrixName != ""},
+			{FlagNameTerragruntScaffoldStageDir, opts.ScaffoldStageDir != ""},
+		} {
+			if flag.isSet {
+				return errors.New(StackManifestConflictError(flag.name))
+			}
 		}
 
-		parsedTemplateURL, err = rewriteTemplateURL(ctx, opts, parsedTemplateURL)
+		generatedDirs, err := generateStack(ctx, opts, vars, boilerplateDir, stack)
+		dirsToClean = append(dirsToClean, generatedDirs...)
+
 		if err != nil {
-			return "", errors.New(err)
+			return err
 		}
-		// regenerate template url with all changes
-		templateURL = parsedTemplateURL.String()
 
-		// prepare temporary directory for template
-		templateDir, err = os.MkdirTemp("", "template")
-		if err != nil {
-			return "", errors.New(err)
+		if err := writeScaffoldLock(opts.WorkingDir, lock); err != nil {
+			return err
 		}
 
-		// downloading template
-		opts.Logger.Infof("Using template from %s", templateURL)
+		opts.Logger.Info("Scaffolding completed")
 
-		if _, err := getter.GetAny(ctx, templateDir, templateURL); err != nil {
-			return "", errors.New(err)
-		}
-	}
-	// prepare boilerplate dir
-	boilerplateDir := util.JoinPath(tempDir, util.DefaultBoilerplateDir)
-	// use template dir as boilerplate dir
-	if templateDir != "" {
-		boilerplateDir = templateDir
+		return nil
 	}
 
-	// if boilerplate dir is not found, create one with default template
-	if !files.IsExistingDir(boilerplateDir) {
-		// no default boilerplate dir, create one
-		defaultTempDir, err := os.MkdirTemp("", "boilerplate")
+	if matrixName == "" {
+		generatedDirs, err := generate(ctx, opts, vars, boilerplateDir)
+		dirsToClean = append(dirsToClean, generatedDirs...)
+
 		if err != nil {
-			return "", errors.New(err)
+			return err
 		}
 
-		boilerplateDir = defaultTempDir
+		if opts.ScaffoldStageDir != "" {
+			if err := writeStageManifest(opts.ScaffoldStageDir, passedModuleURL, moduleURL); err != nil {
+				return err
+			}
 
-		const ownerWriteGlobalReadPerms = 0644
-		if err := os.WriteFile(util.JoinPath(boilerplateDir, "terragrunt.hcl"), []byte(DefaultTerragruntTemplate), ownerWriteGlobalReadPerms); err != nil {
-			return "", errors.New(err)
+			opts.Logger.Infof("Scaffolding staged to %s, review the files and apply them with --%s %s", opts.ScaffoldStageDir, FlagNameTerragruntScaffoldApplyFrom, opts.ScaffoldStageDir)
+
+			return nil
 		}
 
-		if err := os.WriteFile(util.JoinPath(boilerplateDir, "boilerplate.yml"), []byte(DefaultBoilerplateConfig), ownerWriteGlobalReadPerms); err != nil {
-			return "", errors.New(err)
+		if err := writeScaffoldLock(opts.WorkingDir, lock); err != nil {
+			return err
+		}
+
+		opts.Logger.Info("Scaffolding completed")
+
+		return nil
+	}
+
+	// the module is downloaded and parsed only once, and the template is rendered for each matrix value
+	// to the subdirectory named by the value, the failed values don't prevent the rest from being scaffolded
+	errs := &errors.MultiError{}
+
+	for _, value := range matrixValues {
+		generatedDirs, err := generateMatrixValue(ctx, opts, vars, boilerplateDir, matrixName, value)
+		dirsToClean = append(dirsToClean, generatedDirs...)
+
+		if err != nil {
+			errs = errs.Append(err)
 		}
 	}
 
-	return boilerplateDir, nil
+	if err := errs.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	if err := writeScaffoldLock(opts.WorkingDir, lock); err != nil {
+		return err
+	}
+
+	opts.Logger.Info("Scaffolding completed")
+
+	return nil
 }
 
-// parseVariables - parse variables from tf files.
-func parseVariables(opts *options.TerragruntOptions, moduleDir string) ([]*config.ParsedVariable, []*config.ParsedVariable, error) {
-	inputs, err := config.ParseVariables(opts, moduleDir)
-	if err != nil {
-		return nil, nil, errors.New(err)
+// parseMatrix parses the `<name>=<value>[,<value>...]` value of `--terragrunt-scaffold-matrix`, e.g. `environment=dev,prod`.
+// Since the values are used as the names of the subdirectories, they can't contain path separators.
+func parseMatrix(matrix string) (string, []string, error) {
+	if matrix == "" {
+		return "", nil, nil
 	}
 
-	// separate variables that require value and with default value
-	var (
-		requiredVariables []*config.ParsedVariable
-		optionalVariables []*config.ParsedVariable
-	)
+	name, values, found := strings.Cut(matrix, "=")
+	if name = strings.TrimSpace(name); !found || name == "" {
+		return "", nil, errors.New(InvalidMatrixError(matrix))
+	}
 
-	for _, value := range inputs {
-		if value.DefaultValue == "" {
-			requiredVariables = append(requiredVariables, value)
-		} else {
-			optionalVariables = append(optionalVariables, value)
+	var matrixValues []string
+
+	for _, value := range strings.Split(values, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" || value == "." || value == ".." || strings.ContainsAny(value, `/\`) {
+			return "", nil, errors.New(InvalidMatrixError(matrix))
+		}
+
+		if !util.ListContainsElement(matrixValues, value) {
+			matrixValues = append(matrixValues, value)
 		}
 	}
 
-	return requiredVariables, optionalVariables, nil
+	return name, matrixValues, nil
 }
 
-// parseModuleURL - parse module url and rewrite it if required
-func parseModuleURL(ctx context.Context, opts *options.TerragruntOptions, vars map[string]interface{}, moduleURL string) (string, error) {
-	parsedModuleURL, err := terraform.ToSourceURL(moduleURL, opts.WorkingDir)
-	if err != nil {
-		return "", errors.New(err)
-	}
+// generateMatrixValue renders the template to the subdirectory of the working directory named by the given value
+// of the `--terragrunt-scaffold-matrix` variable, which is passed to the template under the variable name.
+func generateMatrixValue(ctx context.Context, opts *options.TerragruntOptions, vars map[string]interface{}, boilerplateDir, name, value string) ([]string, error) {
+	workingDir := filepath.Join(opts.WorkingDir, value)
 
-	moduleURL = parsedModuleURL.String()
+	if err := os.MkdirAll(workingDir, os.ModePerm); err != nil {
+		return nil, errors.New(err)
+	}
 
-	// rewrite module url, if required
-	parsedModuleURL, err = rewriteModuleURL(opts, vars, moduleURL)
+	matrixOpts, err := opts.Clone(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
 	if err != nil {
-		return "", errors.New(err)
+		return nil, errors.New(err)
 	}
 
-	// add ref to module url, if required
-	parsedModuleURL, err = addRefToModuleURL(ctx, opts, parsedModuleURL, vars)
+	matrixVars := make(map[string]interface{}, len(vars)+1)
+	for key, val := range vars {
+		matrixVars[key] = val
+	}
+
+	matrixVars[name] = value
+
+	generatedDirs, err := generate(ctx, matrixOpts, matrixVars, boilerplateDir)
 	if err != nil {
-		return "", errors.New(err)
+		return generatedDirs, errors.New(MatrixValueError{value: value, err: err})
 	}
 
-	// regenerate module url with all changes
-	return parsedModuleURL.String(), nil
+	opts.Logger.Infof("Scaffolding of %s=%s completed", name, value)
+
+	return generatedDirs, nil
 }
 
-// rewriteModuleURL rewrites module url to git ssh if required
-// github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs => git::https://github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs
-func rewriteModuleURL(opts *options.TerragruntOptions, vars map[string]interface{}, moduleURL string) (*url.URL, error) {
-	var updatedModuleURL = moduleURL
+// stackManifest is the manifest of the template generating a stack of units, read from `stack.yml`, e.g.
+//
+//	units:
+//	  - name: network
+//	  - name: cluster
+//	    path: clusters/main
+//	    dependencies: [network]
+//	  - name: app
+//	    template: service
+//	    dependencies: [cluster, network]
+type stackManifest struct {
+	Units []*stackUnit `json:"units"`
+}
 
-	sourceURLType := sourceURLTypeHTTPS
-	if value, found := vars[sourceURLTypeVar]; found {
-		sourceURLType = fmt.Sprintf("%s", value)
-	}
+// stackUnit is a unit of the stack manifest.
+type stackUnit struct {
+	// Name identifies the unit in the dependencies of the other units.
+	Name string `json:"name"`
+	// Path is the slash separated directory of the unit relative to the working directory, the name by default.
+	Path string `json:"path"`
+	// Template is the slash separated subdirectory of the template rendered for the unit, the name by default.
+	Template string `json:"template"`
+	// Dependencies are the names of the units the unit depends on.
+	Dependencies []string `json:"dependencies"`
+}
 
-	// expand module url
-	parsedValue, err := parseURL(opts, moduleURL)
-	if err != nil {
-		opts.Logger.Warnf("Failed to parse module url %s", moduleURL)
+// stackDependency is passed to the unit templates in `unitDependencies`, so that they can generate the dependency
+// blocks, e.g. `dependency "{{ .Name }}" { config_path = "{{ .ConfigPath }}" }`.
+type stackDependency struct {
+	Name string
+	// ConfigPath is the path of the dependency relative to the directory of the unit, e.g. `../network`.
+	ConfigPath string
+}
 
-		parsedModuleURL, err := terraform.ToSourceURL(updatedModuleURL, opts.WorkingDir)
-		if err != nil {
-			return nil, errors.New(err)
-		}
+// readStackManifest reads and validates the stack manifest of the given boilerplate dir, nil is returned if the template
+// does not declare one. The units are returned in the order they are generated, the dependencies before the dependents.
+func readStackManifest(boilerplateDir string) (*stackManifest, error) {
+	manifestPath := filepath.Join(boilerplateDir, StackManifestFile)
+	if !util.FileExists(manifestPath) {
+		return nil, nil
+	}
 
-		return parsedModuleURL, nil
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errors.New(err)
 	}
-	// try to rewrite module url if is https and is requested to be git
-	// git::https://github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs => git::ssh://git@github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs
-	if parsedValue.scheme == "https" && sourceURLType == sourceURLTypeGit {
-		gitUser := sourceGitSSHUser
-		if value, found := vars[sourceGitSSHUserVar]; found {
-			gitUser = fmt.Sprintf("%s", value)
-		}
 
-		path := strings.TrimPrefix(parsedValue.path, "/")
-		updatedModuleURL = fmt.Sprintf("%s@%s:%s", gitUser, parsedValue.host, path)
+	// the manifest is parsed the same way as the boilerplate vars, and converted to the struct through JSON
+	parsed, err := variables.ParseYamlString(string(content))
+	if err != nil {
+		return nil, errors.New(InvalidStackManifestError{file: manifestPath, reason: err.Error()})
 	}
 
-	// persist changes in url.URL
-	parsedModuleURL, err := terraform.ToSourceURL(updatedModuleURL, opts.WorkingDir)
+	jsonContent, err := json.Marshal(parsed)
 	if err != nil {
 		return nil, errors.New(err)
 	}
 
-	return parsedModuleURL, nil
+	manifest := &stackManifest{}
+	if err := json.Unmarshal(jsonContent, manifest); err != nil {
+		return nil, errors.New(InvalidStackManifestError{file: manifestPath, reason: err.Error()})
+	}
+
+	if err := validateStackManifest(boilerplateDir, manifest); err != nil {
+		return nil, errors.New(InvalidStackManifestError{file: manifestPath, reason: err.Error()})
+	}
+
+	if manifest.Units, err = sortStackUnits(manifest.Units); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
 }
 
-// rewriteTemplateURL rewrites template url with reference to tag
-// github.com/denis256/terragrunt-tests.git//scaffold/base-template => github.com/denis256/terragrunt-tests.git//scaffold/base-template?ref=v0.53.8
-func rewriteTemplateURL(ctx context.Context, opts *options.TerragruntOptions, parsedTemplateURL *url.URL) (*url.URL, error) {
-	var (
-		updatedTemplateURL = parsedTemplateURL
-		templateParams     = updatedTemplateURL.Query()
-	)
+// validateStackManifest sets the default paths and templates of the units and checks that the names and the paths
+// are unique, the paths stay within the working directory and the templates are subdirectories of the boilerplate dir.
+func validateStackManifest(boilerplateDir string, manifest *stackManifest) error {
+	if len(manifest.Units) == 0 {
+		return errors.Errorf("no units declared")
+	}
+
+	names := make(map[string]bool, len(manifest.Units))
+	paths := make(map[string]string, len(manifest.Units))
+
+	for _, unit := range manifest.Units {
+		if unit == nil || unit.Name == "" {
+			return errors.Errorf("a unit has no name")
+		}
+
+		if names[unit.Name] {
+			return errors.Errorf("unit %q is declared more than once", unit.Name)
+		}
+
+		names[unit.Name] = true
+
+		if unit.Path == "" {
+			unit.Path = unit.Name
+		}
+
+		if unit.Template == "" {
+			unit.Template = unit.Name
+		}
+
+		for _, dir := range []*string{&unit.Path, &unit.Template} {
+			*dir = path.Clean(filepath.ToSlash(*dir))
+
+			if path.IsAbs(*dir) || filepath.IsAbs(*dir) || *dir == "." || *dir == ".." || strings.HasPrefix(*dir, "../") {
+				return errors.Errorf("unit %q: %s is not a subdirectory", unit.Name, *dir)
+			}
+		}
+
+		if other, ok := paths[unit.Path]; ok {
+			return errors.Errorf("units %q and %q have the same path %s", other, unit.Name, unit.Path)
+		}
+
+		paths[unit.Path] = unit.Name
+
+		if !files.IsExistingDir(filepath.Join(boilerplateDir, filepath.FromSlash(unit.Template))) {
+			return errors.Errorf("unit %q: template directory %s not found", unit.Name, unit.Template)
+		}
+	}
+
+	for _, unit := range manifest.Units {
+		for _, dependency := range unit.Dependencies {
+			if !names[dependency] {
+				return errors.Errorf("unit %q depends on the undeclared unit %q", unit.Name, dependency)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortStackUnits orders the units so that each unit comes after its dependencies, otherwise the declaration order
+// is kept. An error is returned if the units depend on each other.
+func sortStackUnits(units []*stackUnit) ([]*stackUnit, error) {
+	var (
+		byName  = make(map[string]*stackUnit, len(units))
+		visited = make(map[string]bool, len(units))
+		sorted  = make([]*stackUnit, 0, len(units))
+		visit   func(unit *stackUnit, chain []string) error
+	)
+
+	for _, unit := range units {
+		byName[unit.Name] = unit
+	}
+
+	visit = func(unit *stackUnit, chain []string) error {
+		if util.ListContainsElement(chain, unit.Name) {
+			return errors.New(StackDependencyCycleError(append(chain, unit.Name)))
+		}
+
+		if visited[unit.Name] {
+			return nil
+		}
+
+		chain = append(chain, unit.Name)
+
+		for _, dependency := range unit.Dependencies {
+			if err := visit(byName[dependency], chain); err != nil {
+				return err
+			}
+		}
+
+		visited[unit.Name] = true
+		sorted = append(sorted, unit)
+
+		return nil
+	}
+
+	for _, unit := range units {
+		if err := visit(unit, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+// stackUnitDependencies returns the dependencies of the given unit with their paths relative to the unit directory.
+func stackUnitDependencies(manifest *stackManifest, unit *stackUnit) []*stackDependency {
+	paths := make(map[string]string, len(manifest.Units))
+	for _, other := range manifest.Units {
+		paths[other.Name] = other.Path
+	}
+
+	dependencies := make([]*stackDependency, 0, len(unit.Dependencies))
+
+	for _, name := range unit.Dependencies {
+		// both paths are clean and relative to the working directory, so the relative path always exists
+		configPath, _ := filepath.Rel(filepath.FromSlash(unit.Path), filepath.FromSlash(paths[name]))
+
+		dependencies = append(dependencies, &stackDependency{Name: name, ConfigPath: filepath.ToSlash(configPath)})
+	}
+
+	return dependencies
+}
+
+// generateStack renders the template of each unit of the stack manifest to the unit directory, the unit templates get
+// the `unitName`, `unitPath` and `unitDependencies` variables along with the rest of them. Like the matrix values,
+// the failed units don't prevent the rest from being scaffolded.
+func generateStack(ctx context.Context, opts *options.TerragruntOptions, vars map[string]interface{}, boilerplateDir string, manifest *stackManifest) ([]string, error) {
+	var (
+		dirsToClean []string
+		errs        = &errors.MultiError{}
+	)
+
+	for _, unit := range manifest.Units {
+		generatedDirs, err := generateStackUnit(ctx, opts, vars, boilerplateDir, manifest, unit)
+		dirsToClean = append(dirsToClean, generatedDirs...)
+
+		if err != nil {
+			errs = errs.Append(err)
+		}
+	}
+
+	return dirsToClean, errs.ErrorOrNil()
+}
+
+func generateStackUnit(ctx context.Context, opts *options.TerragruntOptions, vars map[string]interface{}, boilerplateDir string, manifest *stackManifest, unit *stackUnit) ([]string, error) {
+	workingDir := filepath.Join(opts.WorkingDir, filepath.FromSlash(unit.Path))
+
+	if err := os.MkdirAll(workingDir, os.ModePerm); err != nil {
+		return nil, errors.New(err)
+	}
+
+	unitOpts, err := opts.Clone(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	const unitVarsCount = 3
+
+	unitVars := make(map[string]interface{}, len(vars)+unitVarsCount)
+	for key, val := range vars {
+		unitVars[key] = val
+	}
+
+	unitVars["unitName"] = unit.Name
+	unitVars["unitPath"] = unit.Path
+	unitVars["unitDependencies"] = stackUnitDependencies(manifest, unit)
+
+	generatedDirs, err := generate(ctx, unitOpts, unitVars, filepath.Join(boilerplateDir, filepath.FromSlash(unit.Template)))
+	if err != nil {
+		return generatedDirs, errors.New(StackUnitError{unit: unit.Name, err: err})
+	}
+
+	opts.Logger.Infof("Scaffolding of unit %s to %s completed", unit.Name, workingDir)
+
+	return generatedDirs, nil
+}
+
+// generate renders the template to the working directory, formats the generated code and runs the post hook.
+// The temporary directories created along the way are returned, so they are cleaned up with the rest of them.
+func generate(ctx context.Context, opts *options.TerragruntOptions, vars map[string]interface{}, boilerplateDir string) ([]string, error) {
+	var (
+		dirsToClean []string
+		err         error
+	)
+
+	// with --terragrunt-scaffold-respect-git or --terragrunt-scaffold-exclude, the files are generated to a temporary
+	// directory first, so they can be filtered and checked against the modified files of the working directory
+	// before they are written
+	outputDir := opts.WorkingDir
+	staged := opts.ScaffoldRespectGit || len(opts.ScaffoldExclude) > 0
+
+	switch {
+	case opts.ScaffoldStageDir != "":
+		// the files are checked and written to the working directory by --terragrunt-scaffold-apply-from
+		outputDir = opts.ScaffoldStageDir
+	case staged:
+		if outputDir, err = os.MkdirTemp("", "scaffold-output"); err != nil {
+			return dirsToClean, errors.New(err)
+		}
+
+		dirsToClean = append(dirsToClean, outputDir)
+	}
+
+	opts.Logger.Infof("Running boilerplate generation to %s", outputDir)
+	boilerplateOpts := &boilerplate_options.BoilerplateOptions{
+		OutputFolder:    outputDir,
+		OnMissingKey:    boilerplate_options.DefaultMissingKeyAction,
+		OnMissingConfig: boilerplate_options.DefaultMissingConfigAction,
+		Vars:            vars,
+		DisableShell:    true,
+		DisableHooks:    true,
+		NonInteractive:  opts.NonInteractive,
+		TemplateFolder:  boilerplateDir,
+	}
+
+	emptyDep := variables.Dependency{}
+	if err := templates.ProcessTemplate(boilerplateOpts, boilerplateOpts, emptyDep); err != nil {
+		return dirsToClean, errors.New(err)
+	}
+
+	// the included files are added before the rest of the steps, so they are excluded, staged and checked
+	// against the modified files the same as the generated ones
+	if err := copyIncludeDir(opts, outputDir); err != nil {
+		return dirsToClean, err
+	}
+
+	if outputDir != opts.WorkingDir {
+		if err := removeExcludedFiles(opts, outputDir); err != nil {
+			return dirsToClean, err
+		}
+	}
+
+	if opts.ScaffoldStageDir != "" {
+		return dirsToClean, formatStagedFiles(opts, outputDir)
+	}
+
+	if opts.ScaffoldRespectGit {
+		if err := checkGitModifiedFiles(ctx, opts, outputDir); err != nil {
+			return dirsToClean, err
+		}
+	}
+
+	if staged {
+		if err := copyGeneratedFiles(outputDir, opts.WorkingDir); err != nil {
+			return dirsToClean, err
+		}
+	}
+
+	opts.Logger.Infof("Running fmt on generated code %s", opts.WorkingDir)
+
+	if err := hclfmt.Run(opts); err != nil {
+		return dirsToClean, errors.New(err)
+	}
+
+	if err := validateGeneratedFiles(opts, opts.WorkingDir); err != nil {
+		return dirsToClean, err
+	}
+
+	checkRootInclude(opts, vars)
+
+	if err := runPostHook(ctx, opts); err != nil {
+		return dirsToClean, err
+	}
+
+	return dirsToClean, nil
+}
+
+// checkGitModifiedFiles returns an error if any of the files generated to the given dir would overwrite a tracked file
+// of the working directory with uncommitted changes. Untracked and unmodified files can be overwritten.
+// If the working directory is not a git working tree, there is nothing to check.
+func checkGitModifiedFiles(ctx context.Context, opts *options.TerragruntOptions, generatedDir string) error {
+	if _, err := shell.RunGitCommandOutput(ctx, opts, opts.WorkingDir, "rev-parse", "--is-inside-work-tree"); err != nil {
+		opts.Logger.Warnf("The working directory %s is not a git working tree, --%s is ignored", opts.WorkingDir, FlagNameTerragruntScaffoldRespectGit)
+		return nil
+	}
+
+	modifiedFiles, err := gitModifiedFiles(ctx, opts, opts.WorkingDir)
+	if err != nil {
+		return err
+	}
+
+	var overwrittenFiles []string
+
+	err = filepath.WalkDir(generatedDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel(generatedDir, path)
+		if err != nil {
+			return err
+		}
+
+		relPath = filepath.ToSlash(relPath)
+		if util.ListContainsElement(modifiedFiles, relPath) {
+			overwrittenFiles = append(overwrittenFiles, relPath)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if len(overwrittenFiles) > 0 {
+		return errors.New(ModifiedFilesOverwriteError(overwrittenFiles))
+	}
+
+	return nil
+}
+
+// gitModifiedFiles returns the paths, relative to the given dir, of the tracked files in the dir which have
+// staged or unstaged changes, including deleted files.
+func gitModifiedFiles(ctx context.Context, opts *options.TerragruntOptions, dir string) ([]string, error) {
+	var modifiedFiles []string
+
+	for _, args := range [][]string{
+		{"ls-files", "--modified", "-z"},
+		{"diff", "--cached", "--name-only", "--relative", "-z"},
+	} {
+		output, err := shell.RunGitCommandOutput(ctx, opts, dir, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, path := range strings.Split(output, "\x00") {
+			if path != "" && !util.ListContainsElement(modifiedFiles, path) {
+				modifiedFiles = append(modifiedFiles, path)
+			}
+		}
+	}
+
+	return modifiedFiles, nil
+}
+
+// stageManifest is written along with the files generated with `--terragrunt-scaffold-stage-dir`, so that
+// `--terragrunt-scaffold-apply-from` can verify that the staged files were generated for the same module.
+type stageManifest struct {
+	// ModuleURL is the module url passed to the command.
+	ModuleURL string `json:"module_url"`
+	// SourceURL is the module url the files were generated from, with the resolved ref.
+	SourceURL string `json:"source_url"`
+	// Ref is the resolved ref of the module, empty if the module is not pinned.
+	Ref string `json:"ref,omitempty"`
+	// Files are the slash separated paths of the generated files, relative to the stage dir.
+	Files []string `json:"files"`
+}
+
+// writeStageManifest writes the manifest of the files of the given stage dir generated for the given module url,
+// the source url is the module url with the resolved ref the files were generated from.
+func writeStageManifest(stageDir, moduleURL, sourceURL string) error {
+	manifest := &stageManifest{ModuleURL: moduleURL, SourceURL: sourceURL}
+
+	if parsedSourceURL, err := url.Parse(sourceURL); err == nil {
+		manifest.Ref = parsedSourceURL.Query().Get(refParam)
+	}
+
+	err := filepath.WalkDir(stageDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel(stageDir, path)
+		if err != nil {
+			return err
+		}
+
+		if relPath != StageManifestFile {
+			manifest.Files = append(manifest.Files, filepath.ToSlash(relPath))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.New(err)
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	const ownerWriteGlobalReadPerms = 0644
+
+	if err := os.WriteFile(filepath.Join(stageDir, StageManifestFile), content, ownerWriteGlobalReadPerms); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func readStageManifest(stageDir string) (*stageManifest, error) {
+	content, err := os.ReadFile(filepath.Join(stageDir, StageManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New(StageManifestNotFoundError(stageDir))
+		}
+
+		return nil, errors.New(err)
+	}
+
+	manifest := &stageManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return manifest, nil
+}
+
+// formatStagedFiles formats the files generated to the stage dir, so that they are reviewed as they will be applied.
+func formatStagedFiles(opts *options.TerragruntOptions, stageDir string) error {
+	stageOpts, err := opts.Clone(filepath.Join(stageDir, config.DefaultTerragruntConfigPath))
+	if err != nil {
+		return errors.New(err)
+	}
+
+	opts.Logger.Infof("Running fmt on staged code %s", stageDir)
+
+	if err := hclfmt.Run(stageOpts); err != nil {
+		return errors.New(err)
+	}
+
+	return validateGeneratedFiles(opts, stageDir)
+}
+
+// validateGeneratedFiles parses the `.hcl` files generated to the given dir, the same files formatted by `hclfmt`,
+// to catch the template mistakes at scaffold time instead of at the next run. The Terragrunt configs, including the ones
+// renamed with `--terragrunt-scaffold-template-file`, are also checked for unsupported blocks and attributes,
+// see `config.ValidateConfigSchema`. The expressions are not evaluated,
+// since the generated config may depend on the configs which don't exist yet, such as the root config.
+func validateGeneratedFiles(opts *options.TerragruntOptions, generatedDir string) error {
+	var configFiles []string
+
+	err := filepath.WalkDir(generatedDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if name := entry.Name(); name == util.TerragruntCacheDir || name == util.DefaultBoilerplateDir {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if filepath.Ext(path) == hclFileExt {
+			configFiles = append(configFiles, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.New(err)
+	}
+
+	var validationErrs *errors.MultiError
+
+	for _, configFile := range configFiles {
+		opts.Logger.Debugf("Validating generated %s", configFile)
+
+		if name := filepath.Base(configFile); name == config.DefaultTerragruntConfigPath || name == configFileName(opts) {
+			err = config.ValidateConfigSchema(opts, configFile)
+		} else {
+			_, err = hclparse.NewParser(hclparse.WithLogger(opts.Logger)).ParseFromFile(configFile)
+		}
+
+		if err != nil {
+			validationErrs = validationErrs.Append(errors.New(InvalidGeneratedFileError{file: configFile, err: err}))
+		}
+	}
+
+	return validationErrs.ErrorOrNil()
+}
+
+// applyStagedFiles copies the files staged with `--terragrunt-scaffold-stage-dir` to the working directory, after
+// verifying the staged module matches the given module url, if it's passed. The files are formatted again,
+// in case they were edited during the review, and the post hook runs as after scaffolding.
+func applyStagedFiles(ctx context.Context, opts *options.TerragruntOptions, moduleURL string) error {
+	stageDir := opts.ScaffoldApplyFrom
+
+	manifest, err := readStageManifest(stageDir)
+	if err != nil {
+		return err
+	}
+
+	if moduleURL != "" && moduleURL != manifest.ModuleURL {
+		return errors.New(StagedModuleMismatchError{stageDir: stageDir, staged: manifest.ModuleURL, passed: moduleURL})
+	}
+
+	opts.Logger.Infof("Applying the files staged in %s for the module %s to %s", stageDir, redactSourceURL(manifest.SourceURL), opts.WorkingDir)
+
+	if opts.ScaffoldRespectGit {
+		if err := checkGitModifiedFiles(ctx, opts, stageDir); err != nil {
+			return err
+		}
+	}
+
+	if err := copyGeneratedFiles(stageDir, opts.WorkingDir); err != nil {
+		return err
+	}
+
+	opts.Logger.Infof("Running fmt on applied code %s", opts.WorkingDir)
+
+	if err := hclfmt.Run(opts); err != nil {
+		return errors.New(err)
+	}
+
+	if err := validateGeneratedFiles(opts, opts.WorkingDir); err != nil {
+		return err
+	}
+
+	if err := runPostHook(ctx, opts); err != nil {
+		return err
+	}
+
+	opts.Logger.Info("Scaffolding completed")
+
+	return nil
+}
+
+// resolveIncludeDir makes the `--terragrunt-scaffold-include-dir` path absolute, relative paths are resolved against
+// the working dir, and checks that the directory exists before anything is downloaded.
+func resolveIncludeDir(opts *options.TerragruntOptions) error {
+	if opts.ScaffoldIncludeDir == "" {
+		return nil
+	}
+
+	if !filepath.IsAbs(opts.ScaffoldIncludeDir) {
+		opts.ScaffoldIncludeDir = filepath.Join(opts.WorkingDir, opts.ScaffoldIncludeDir)
+	}
+
+	if !files.IsExistingDir(opts.ScaffoldIncludeDir) {
+		return errors.New(IncludeDirNotFoundError(opts.ScaffoldIncludeDir))
+	}
+
+	return nil
+}
+
+// copyIncludeDir copies the files of `--terragrunt-scaffold-include-dir` to the given output dir as is, without
+// rendering them as templates. The included files are layered on top of the template, so the generated files
+// with the same paths are overwritten, which is logged as a warning.
+func copyIncludeDir(opts *options.TerragruntOptions, outputDir string) error {
+	if opts.ScaffoldIncludeDir == "" {
+		return nil
+	}
+
+	err := filepath.WalkDir(opts.ScaffoldIncludeDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel(opts.ScaffoldIncludeDir, path)
+		if err != nil {
+			return err
+		}
+
+		if util.FileExists(filepath.Join(outputDir, relPath)) {
+			opts.Logger.Warnf("The generated %s is overwritten by the file of --%s", filepath.ToSlash(relPath), FlagNameTerragruntScaffoldIncludeDir)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.New(err)
+	}
+
+	opts.Logger.Debugf("Copying the files of %s to %s", opts.ScaffoldIncludeDir, outputDir)
+
+	return copyGeneratedFiles(opts.ScaffoldIncludeDir, outputDir)
+}
+
+// removeExcludedFiles removes the files and directories generated to the given dir which match any of the globs
+// of `--terragrunt-scaffold-exclude`. The globs are matched against the slash separated paths relative to the dir,
+// the globs without a slash, e.g. `README.md`, are also matched against the base names of the files in any directory.
+func removeExcludedFiles(opts *options.TerragruntOptions, generatedDir string) error {
+	if len(opts.ScaffoldExclude) == 0 {
+		return nil
+	}
+
+	var excludedPaths []string
+
+	err := filepath.WalkDir(generatedDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || path == generatedDir {
+			return err
+		}
+
+		relPath, err := filepath.Rel(generatedDir, path)
+		if err != nil {
+			return err
+		}
+
+		relPath = filepath.ToSlash(relPath)
+
+		excluded, err := matchExcludeGlobs(opts.ScaffoldExclude, relPath)
+		if err != nil || !excluded {
+			return err
+		}
+
+		excludedPaths = append(excludedPaths, path)
+
+		if entry.IsDir() {
+			return fs.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.New(err)
+	}
+
+	for _, path := range excludedPaths {
+		relPath, _ := filepath.Rel(generatedDir, path)
+		opts.Logger.Debugf("Excluding generated %s", relPath)
+
+		if err := os.RemoveAll(path); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// matchExcludeGlobs returns true if the given slash separated relative path matches any of the given globs.
+func matchExcludeGlobs(globs []string, relPath string) (bool, error) {
+	for _, glob := range globs {
+		glob = strings.TrimPrefix(filepath.ToSlash(glob), "./")
+
+		name := relPath
+		if !strings.Contains(glob, "/") {
+			name = path.Base(relPath)
+		}
+
+		matched, err := matchGlobSegments(strings.Split(glob, "/"), strings.Split(name, "/"))
+		if err != nil {
+			return false, errors.New(InvalidExcludeGlobError(glob))
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// matchGlobSegments matches the path segments against the glob segments, the `**` segment matches any number of
+// path segments, the rest are matched by `path.Match`.
+func matchGlobSegments(globSegments, pathSegments []string) (bool, error) {
+	for len(globSegments) > 0 {
+		if globSegments[0] == "**" {
+			for skip := 0; skip <= len(pathSegments); skip++ {
+				if matched, err := matchGlobSegments(globSegments[1:], pathSegments[skip:]); matched || err != nil {
+					return matched, err
+				}
+			}
+
+			return false, nil
+		}
+
+		if len(pathSegments) == 0 {
+			return false, nil
+		}
+
+		if matched, err := path.Match(globSegments[0], pathSegments[0]); !matched || err != nil {
+			return false, err
+		}
+
+		globSegments, pathSegments = globSegments[1:], pathSegments[1:]
+	}
+
+	return len(pathSegments) == 0, nil
+}
+
+// copyGeneratedFiles copies the files generated to the given dir to the destination dir, overwriting the existing files.
+// The stage manifest is not copied.
+func copyGeneratedFiles(generatedDir, destDir string) error {
+	const ownerReadWriteExecutePerms = 0755
+
+	err := filepath.WalkDir(generatedDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(generatedDir, path)
+		if err != nil || relPath == StageManifestFile {
+			return err
+		}
+
+		destPath := filepath.Join(destDir, relPath)
+
+		if entry.IsDir() {
+			return os.MkdirAll(destPath, ownerReadWriteExecutePerms)
+		}
+
+		return util.CopyFile(path, destPath)
+	})
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// checkRootInclude logs whether the root config included by the generated config, if `EnableRootInclude` is true,
+// is found by `find_in_parent_folders`. The variable is true by default, as in the built-in templates.
+func checkRootInclude(opts *options.TerragruntOptions, vars map[string]interface{}) {
+	if _, found := vars[enableRootIncludeVar]; found {
+		if enabled, err := boolVar(vars, enableRootIncludeVar); err != nil || !enabled {
+			return
+		}
+	}
+
+	if rootConfig, found := findRootConfig(opts); found {
+		opts.Logger.Infof("The generated config includes the root config %s", rootConfig)
+		return
+	}
+
+	opts.Logger.Warnf("The generated config includes the root config, but no config is found in the parent folders of %s, so it will fail to load until one is added.", opts.WorkingDir)
+}
+
+// findRootConfig returns the Terragrunt config found in the parent folders of the working directory, the same way
+// `find_in_parent_folders()` does for the generated config.
+func findRootConfig(opts *options.TerragruntOptions) (string, bool) {
+	previousDir, err := filepath.Abs(opts.WorkingDir)
+	if err != nil {
+		return "", false
+	}
+
+	for i := 0; i < opts.MaxFoldersToCheck; i++ {
+		currentDir := filepath.Dir(previousDir)
+		if currentDir == previousDir {
+			return "", false
+		}
+
+		if configPath := config.GetDefaultConfigPath(currentDir); util.FileExists(configPath) {
+			return configPath, true
+		}
+
+		previousDir = currentDir
+	}
+
+	return "", false
+}
+
+// runPostHook runs the `--terragrunt-scaffold-post-hook` command in the working directory, the command output is written to the log.
+// In the dry-run mode, the hook is skipped.
+func runPostHook(ctx context.Context, opts *options.TerragruntOptions) error {
+	if opts.ScaffoldPostHook == "" {
+		return nil
+	}
+
+	args, err := shlex.Split(opts.ScaffoldPostHook)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// the hook is skipped even if it's read-only, since nothing was generated for it to work on
+	if opts.DryRun {
+		opts.Logger.Infof("Skipping post hook %q in dry-run mode", opts.ScaffoldPostHook)
+		return nil
+	}
+
+	opts.Logger.Infof("Running post hook %q in %s", opts.ScaffoldPostHook, opts.WorkingDir)
+
+	hookOpts, err := opts.Clone(opts.TerragruntConfigPath)
+	if err != nil {
+		return err
+	}
+
+	hookOpts.Writer = writer.New(writer.WithLogger(opts.Logger), writer.WithDefaultLevel(log.StdoutLevel))
+	hookOpts.ErrWriter = writer.New(writer.WithLogger(opts.Logger), writer.WithDefaultLevel(log.StderrLevel))
+
+	if _, err := shell.RunShellCommandWithOutput(ctx, hookOpts, opts.WorkingDir, false, false, args[0], args[1:]...); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// getAny downloads the given source url to the destination directory, like `getter.GetAny`.
+// If `--terragrunt-scaffold-auth-header` is set, its value is sent as the `Authorization` header of HTTP(S) downloads.
+func getAny(ctx context.Context, opts *options.TerragruntOptions, dst, src string) (*getter.GetResult, error) {
+	req := &getter.Request{
+		Src:     src,
+		Dst:     dst,
+		GetMode: getter.ModeAny,
+	}
+
+	return newGetterClient(opts).Get(ctx, req)
+}
+
+// newGetterClient returns the go-getter client with the default getters and the getters of the S3 and GCS sources.
+// If the `Authorization` header is set, the HTTP getter is replaced by the one sending the header.
+func newGetterClient(opts *options.TerragruntOptions) *getter.Client {
+	header := http.Header{}
+	if opts.ScaffoldAuthHeader != "" {
+		header.Set(authorizationHeader, opts.ScaffoldAuthHeader)
+	}
+
+	getters := make([]getter.Getter, 0, len(getter.Getters)+2) //nolint:mnd
+
+	for _, g := range getter.Getters {
+		// copy the getter instead of changing the globally shared one
+		if httpGetter, ok := g.(*getter.HttpGetter); ok && len(header) > 0 {
+			authGetter := *httpGetter
+			authGetter.Header = header
+			g = &authGetter
+		}
+
+		getters = append(getters, g)
+	}
+
+	getters = append(getters, newS3ObjectStoreGetter(opts), newGCSObjectStoreGetter(opts))
+
+	return &getter.Client{
+		Getters:       getters,
+		Decompressors: getter.Decompressors,
+	}
+}
+
+// redactSourceURL masks the credentials in the user info of the given source url, so they are not logged.
+// The user of non HTTP(S) urls is kept, since it is usually not a secret, e.g. `git@github.com`.
+func redactSourceURL(sourceURL string) string {
+	sourceURL = httpUserInfoRegex.ReplaceAllString(sourceURL, "${1}"+redactedUserInfo+"@")
+
+	return userInfoPasswordRegex.ReplaceAllString(sourceURL, "${1}:"+redactedUserInfo+"@")
+}
+
+// prepareVarFiles downloads remote var files, passed as go-getter URLs, and returns the paths to the local var files
+// along with the temporary directories where the remote var files are downloaded to.
+// git::https://github.com/acme/scaffold-defaults.git//defaults.yml?ref=v0.1.0 => /tmp/scaffold-var-file123/defaults.yml
+func prepareVarFiles(ctx context.Context, opts *options.TerragruntOptions) ([]string, []string, error) {
+	var (
+		varFiles = make([]string, 0, len(opts.ScaffoldVarFiles))
+		tempDirs []string
+	)
+
+	for _, varFile := range opts.ScaffoldVarFiles {
+		if util.FileExists(varFile) {
+			varFiles = append(varFiles, varFile)
+			continue
+		}
+
+		sourceURL, err := terraform.ToSourceURL(varFile, opts.WorkingDir)
+		if err != nil || sourceURL.Scheme == "" || sourceURL.Scheme == "file" {
+			// not a remote URL, leave it to be reported by the vars parser
+			varFiles = append(varFiles, varFile)
+			continue
+		}
+
+		tempDir, err := os.MkdirTemp("", "scaffold-var-file")
+		if err != nil {
+			return nil, tempDirs, errors.New(err)
+		}
+
+		tempDirs = append(tempDirs, tempDir)
+
+		opts.Logger.Infof("Downloading var file from %s", redactSourceURL(sourceURL.String()))
+
+		localVarFile, err := downloadVarFile(ctx, opts, sourceURL, tempDir)
+		if err != nil {
+			return nil, tempDirs, err
+		}
+
+		varFiles = append(varFiles, localVarFile)
+	}
+
+	return varFiles, tempDirs, nil
+}
+
+// downloadVarFile downloads the var file from the given URL to the given directory and returns the local file path.
+func downloadVarFile(ctx context.Context, opts *options.TerragruntOptions, sourceURL *url.URL, dstDir string) (string, error) {
+	rootSourceURL, filePath, err := terraform.SplitSourceURL(sourceURL, opts.Logger)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	localVarFile := ""
+
+	if filePath != "" {
+		// the var file is inside of a repository, download the whole repository
+		if _, err := getAny(ctx, opts, dstDir, rootSourceURL.String()); err != nil {
+			return "", errors.New(err)
+		}
+
+		localVarFile = filepath.Join(dstDir, filePath)
+	} else {
+		result, err := getAny(ctx, opts, dstDir, sourceURL.String())
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		localVarFile = result.Dst
+	}
+
+	if !util.FileExists(localVarFile) || util.IsDir(localVarFile) {
+		return "", errors.New(VarFileNotFoundError(sourceURL.String()))
+	}
+
+	return localVarFile, nil
+}
+
+// parseScaffoldVars parses scaffold variables, `--var` flags take precedence over `TERRAGRUNT_SCAFFOLD_VAR_` prefixed
+// environment variables, which take precedence over var files.
+func parseScaffoldVars(opts *options.TerragruntOptions, varFiles []string) (map[string]interface{}, error) {
+	fileVars, err := variables.ParseVars(nil, varFiles)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	envVars := map[string]interface{}{}
+
+	for name, value := range opts.Env {
+		// the remainder of the name keeps its casing, e.g. TERRAGRUNT_SCAFFOLD_VAR_Ref => Ref
+		varName, found := strings.CutPrefix(name, scaffoldVarEnvPrefix)
+		if !found || varName == "" {
+			continue
+		}
+
+		parsedValue, err := variables.ParseYamlString(value)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		envVars[varName] = parsedValue
+	}
+
+	flagVars, err := variables.ParseVars(opts.ScaffoldVars, nil)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	jsonVars, err := parseJSONVars(opts.ScaffoldVarsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make(map[string]interface{}, len(fileVars)+len(envVars)+len(flagVars)+len(jsonVars))
+
+	for _, source := range []map[string]interface{}{fileVars, envVars, flagVars, jsonVars} {
+		for name, value := range source {
+			vars[name] = value
+		}
+	}
+
+	return vars, nil
+}
+
+// parseJSONVars parses the `<name>=<json>` values of `--terragrunt-scaffold-var-json`, e.g. `tags={"team":"platform"}`,
+// to the decoded values, so the templates can range over the lists and the maps.
+func parseJSONVars(jsonVars []string) (map[string]interface{}, error) {
+	vars := make(map[string]interface{}, len(jsonVars))
+
+	for _, jsonVar := range jsonVars {
+		name, value, found := strings.Cut(jsonVar, "=")
+		if name = strings.TrimSpace(name); !found || name == "" {
+			return nil, errors.New(InvalidVarJSONError{value: jsonVar, err: errors.Errorf("expected <name>=<json>")})
+		}
+
+		var parsedValue interface{}
+
+		if err := json.Unmarshal([]byte(value), &parsedValue); err != nil {
+			return nil, errors.New(InvalidVarJSONError{name: name, value: value, err: err})
+		}
+
+		vars[name] = parsedValue
+	}
+
+	return vars, nil
+}
+
+// prepareBoilerplateFiles prepares boilerplate files, the remote template url is downloaded as resolved by `resolveTemplateURL`.
+func prepareBoilerplateFiles(ctx context.Context, opts *options.TerragruntOptions, templateURL, tempDir string) (string, error) {
+	// identify template url
+	templateDir := ""
+
+	if templateURL == stdinTemplateURL {
+		dir, err := writeStdinTemplate(opts, os.Stdin)
+		if err != nil {
+			return "", err
+		}
+
+		templateDir = dir
+	} else if localDir, ok := localTemplateDir(opts, templateURL); ok {
+		// the local directory is used as is, without the url rewriting and the download
+		opts.Logger.Infof("Using template from %s", localDir)
+
+		dir, err := useLocalTemplateDir(opts, localDir)
+		if err != nil {
+			return "", err
+		}
+
+		templateDir = dir
+	} else if templateURL != "" {
+		// prepare temporary directory for template
+		var err error
+
+		templateDir, err = os.MkdirTemp("", "template")
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		// downloading template
+		opts.Logger.Infof("Using template from %s", redactSourceURL(templateURL))
+
+		if _, err := getAny(ctx, opts, templateDir, templateURL); err != nil {
+			return "", errors.New(err)
+		}
+	}
+	// prepare boilerplate dir
+	boilerplateDir := util.JoinPath(tempDir, util.DefaultBoilerplateDir)
+	// use template dir as boilerplate dir
+	if templateDir != "" {
+		boilerplateDir = templateDir
+	}
+
+	// if boilerplate dir is not found, create one with default template
+	if files.IsExistingDir(boilerplateDir) {
+		if err := prepareBoilerplateConfig(opts, boilerplateDir); err != nil {
+			return "", err
+		}
+	} else {
+		// no default boilerplate dir, create one
+		defaultTempDir, err := os.MkdirTemp("", "boilerplate")
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		boilerplateDir = defaultTempDir
+
+		if err := writeTemplatePreset(opts, boilerplateDir); err != nil {
+			return "", err
+		}
+	}
+
+	return boilerplateDir, nil
+}
+
+// resolveTemplateURL returns the remote template url pinned to the `Ref` variable or the last release tag,
+// the stdin, local and empty template urls are returned as is.
+func resolveTemplateURL(ctx context.Context, opts *options.TerragruntOptions, vars map[string]interface{}, moduleURL, templateURL, tempDir string) (string, error) {
+	if templateURL == "" || templateURL == stdinTemplateURL {
+		return templateURL, nil
+	}
+
+	if _, ok := localTemplateDir(opts, templateURL); ok {
+		return templateURL, nil
+	}
+
+	parsedTemplateURL, err := terraform.ToSourceURL(templateURL, tempDir)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	parsedTemplateURL, err = rewriteTemplateURL(ctx, opts, parsedTemplateURL, vars)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	if err := checkRefMismatch(opts, moduleURL, parsedTemplateURL); err != nil {
+		return "", err
+	}
+
+	// regenerate template url with all changes
+	return parsedTemplateURL.String(), nil
+}
+
+// writeTemplatePreset writes the files of the built-in template selected with `--terragrunt-scaffold-template-preset`
+// to the given boilerplate dir.
+func writeTemplatePreset(opts *options.TerragruntOptions, boilerplateDir string) error {
+	presetFiles, err := templatePresetFiles(opts.ScaffoldTemplatePreset)
+	if err != nil {
+		return err
+	}
+
+	const ownerWriteGlobalReadPerms = 0644
+
+	for name, content := range presetFiles {
+		if name == DefaultTerragruntTemplateFile {
+			name = configFileName(opts)
+		}
+
+		if err := os.WriteFile(util.JoinPath(boilerplateDir, name), []byte(content), ownerWriteGlobalReadPerms); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// configFileName returns the name of the generated Terragrunt config, set with `--terragrunt-scaffold-template-file`.
+// The built-in templates write the config to this file, while the custom templates can use it as `{{ .configFile }}`
+// in their file names, otherwise the names of their files are kept.
+func configFileName(opts *options.TerragruntOptions) string {
+	if opts.ScaffoldTemplateFile != "" {
+		return opts.ScaffoldTemplateFile
+	}
+
+	return DefaultTerragruntTemplateFile
+}
+
+// prepareBoilerplateConfig finds the config file in the given boilerplate dir and renames it to the name read by
+// boilerplate. The config file name is taken from the options, otherwise both `.yml` and `.yaml` extensions are detected.
+func prepareBoilerplateConfig(opts *options.TerragruntOptions, boilerplateDir string) error {
+	configFile, err := findBoilerplateConfig(opts, boilerplateDir)
+	if err != nil || configFile == "" || configFile == DefaultBoilerplateConfigFile {
+		return err
+	}
+
+	configPath := util.JoinPath(boilerplateDir, configFile)
+	opts.Logger.Debugf("Using boilerplate config %s", configPath)
+
+	if err := os.Rename(configPath, util.JoinPath(boilerplateDir, DefaultBoilerplateConfigFile)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// findBoilerplateConfig returns the name of the config file in the given boilerplate dir, or the empty string if the dir
+// has no config file and the name is not set in the options.
+func findBoilerplateConfig(opts *options.TerragruntOptions, boilerplateDir string) (string, error) {
+	configFiles := []string{DefaultBoilerplateConfigFile, "boilerplate.yaml"}
+	if opts.ScaffoldConfigFile != "" {
+		configFiles = []string{opts.ScaffoldConfigFile}
+	}
+
+	for _, configFile := range configFiles {
+		if util.FileExists(util.JoinPath(boilerplateDir, configFile)) {
+			return configFile, nil
+		}
+	}
+
+	if opts.ScaffoldConfigFile != "" {
+		return "", errors.New(BoilerplateConfigNotFoundError(opts.ScaffoldConfigFile))
+	}
+
+	return "", nil
+}
+
+// localTemplateDir returns the absolute path of the template if the template url is a path to an existing local
+// directory, relative paths are resolved against the working dir. The urls with a scheme or a forced getter,
+// e.g. `git::`, are never treated as local paths.
+func localTemplateDir(opts *options.TerragruntOptions, templateURL string) (string, bool) {
+	if templateURL == "" || templateURL == stdinTemplateURL || strings.Contains(templateURL, "::") || strings.Contains(templateURL, "://") {
+		return "", false
+	}
+
+	dir := templateURL
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(opts.WorkingDir, dir)
+	}
+
+	if !files.IsExistingDir(dir) {
+		return "", false
+	}
+
+	return filepath.Clean(dir), true
+}
+
+// writeStdinTemplate writes the single file template read from the given reader to a temporary boilerplate dir,
+// as the generated Terragrunt config, along with a boilerplate config declaring no variables. The variables passed
+// to scaffold and the ones extracted from the module are still available to the template.
+func writeStdinTemplate(opts *options.TerragruntOptions, reader io.Reader) (string, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		return "", errors.New(EmptyStdinTemplateError{})
+	}
+
+	templateDir, err := os.MkdirTemp("", "template")
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	opts.Logger.Infof("Using template from stdin")
+
+	const ownerWriteGlobalReadPerms = 0644
+
+	templateFiles := map[string][]byte{
+		configFileName(opts):         content,
+		DefaultBoilerplateConfigFile: []byte(stdinBoilerplateConfig),
+	}
+
+	for name, content := range templateFiles {
+		if err := os.WriteFile(util.JoinPath(templateDir, name), content, ownerWriteGlobalReadPerms); err != nil {
+			return "", errors.New(err)
+		}
+	}
+
+	return templateDir, nil
+}
+
+// useLocalTemplateDir returns the local template dir to use as the boilerplate dir. Since the files of the local
+// template must not be changed, it's copied to a temporary directory only if its config file has to be renamed.
+func useLocalTemplateDir(opts *options.TerragruntOptions, localDir string) (string, error) {
+	configFile, err := findBoilerplateConfig(opts, localDir)
+	if err != nil {
+		return "", err
+	}
+
+	if configFile == "" || configFile == DefaultBoilerplateConfigFile {
+		return localDir, nil
+	}
+
+	templateDir, err := os.MkdirTemp("", "template")
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	// unlike `util.CopyFolderContents`, the dot files, such as `.terraform-version`, are copied as well
+	if err := copyGeneratedFiles(localDir, templateDir); err != nil {
+		return "", err
+	}
+
+	return templateDir, nil
+}
+
+// parseModuleVariables parses the variables of the module. By default, any file that fails to parse fails scaffolding,
+// if `--terragrunt-scaffold-strict-parse` is disabled, such files are skipped with a warning instead.
+func parseModuleVariables(opts *options.TerragruntOptions, moduleDir string) ([]*config.ParsedVariable, error) {
+	if opts.ScaffoldStrictParse {
+		return config.ParseVariables(opts, moduleDir)
+	}
+
+	inputs, err := config.ParseVariablesLenient(opts, moduleDir)
+
+	var multiErr *errors.MultiError
+	if errors.As(err, &multiErr) {
+		for _, err := range multiErr.WrappedErrors() {
+			opts.Logger.Warnf("Skipping the variables of the file that failed to parse. %v", err)
+		}
+
+		return inputs, nil
+	}
+
+	return inputs, err
+}
+
+// parseVariables - parse variables from tf files, and split them into the required and the optional variables
+// in the declaration order, with the descriptions normalized and wrapped as requested by the vars.
+func parseVariables(opts *options.TerragruntOptions, vars map[string]interface{}, moduleDir string) ([]*config.ParsedVariable, []*config.ParsedVariable, error) {
+	inputs, err := parseModuleVariables(opts, moduleDir)
+	if err != nil {
+		return nil, nil, errors.New(ParseVariablesError{err: err})
+	}
+
+	if len(inputs) == 0 {
+		opts.Logger.Infof("Module defines no input variables")
+	}
+
+	wrapWidth, err := intVar(vars, descriptionWrapWidthVar)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// keep the declaration order of the variables, since the files are parsed in random order
+	sort.SliceStable(inputs, func(i, j int) bool {
+		a, b := inputs[i].DeclRange, inputs[j].DeclRange
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+
+		return a.Start.Byte < b.Start.Byte
+	})
+
+	// descriptions are kept byte for byte as written in the module, unless normalization is requested
+	normalizeDescriptions, err := boolVar(vars, normalizeDescriptionsVar)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if normalizeDescriptions {
+		for _, input := range inputs {
+			input.Description = norm.NFC.String(input.Description)
+		}
+	}
+
+	if wrapWidth > 0 {
+		for _, input := range inputs {
+			// explicit line breaks are preserved, long lines are broken at word boundaries
+			input.Description = wordwrap.WrapString(input.Description, uint(wrapWidth))
+		}
+	}
+
+	// separate variables that require value and with default value,
+	// `default = null` is treated as a default value, since the module doesn't require to set it
+	var (
+		requiredVariables []*config.ParsedVariable
+		optionalVariables []*config.ParsedVariable
+	)
+
+	for _, value := range inputs {
+		if value.DefaultValue == "" {
+			requiredVariables = append(requiredVariables, value)
+		} else {
+			optionalVariables = append(optionalVariables, value)
+		}
+	}
+
+	return requiredVariables, optionalVariables, nil
+}
+
+// modulePath returns the path of the module inside of its repository, or the repository name if the module is in the root.
+// git::https://github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs?ref=v0.53.8 => test/fixtures/inputs
+// git::https://github.com/gruntwork-io/terraform-aws-vpc.git?ref=v0.1.0 => terraform-aws-vpc
+func modulePath(opts *options.TerragruntOptions, moduleURL string) string {
+	parsedModuleURL, err := terraform.ToSourceURL(moduleURL, opts.WorkingDir)
+	if err != nil {
+		return ""
+	}
+
+	rootSourceURL, subDir, err := terraform.SplitSourceURL(parsedModuleURL, opts.Logger)
+	if err != nil {
+		return ""
+	}
+
+	if subDir = strings.Trim(subDir, "/"); subDir != "" {
+		return subDir
+	}
+
+	return strings.TrimSuffix(filepath.Base(rootSourceURL.Path), ".git")
+}
+
+// minimumRequiredVersion returns the lowest version of the lower bounds of the given constraint which satisfies
+// the whole constraint, e.g. `1.5.0` for `>= 1.5, < 2.0`. Returns an empty string if there is no such version,
+// e.g. for `> 1.5` or `< 2.0`, or the constraint is invalid.
+func minimumRequiredVersion(constraint string) string {
+	constraints, err := version.NewConstraint(constraint)
+	if err != nil {
+		return ""
+	}
+
+	var minimum *version.Version
+
+	for _, part := range strings.Split(constraint, ",") {
+		match := versionLowerBoundRegex.FindStringSubmatch(strings.TrimSpace(part))
+		if match == nil {
+			continue
+		}
+
+		ver, err := version.NewVersion(match[1])
+		if err != nil || !constraints.Check(ver) {
+			continue
+		}
+
+		if minimum == nil || ver.LessThan(minimum) {
+			minimum = ver
+		}
+	}
+
+	if minimum == nil {
+		return ""
+	}
+
+	return minimum.String()
+}
+
+// readModuleDescription returns the description section of the README of the module in the given dir, that is the text
+// between the title and the next heading, e.g. the usage section. Returns an empty string if the module has no README.
+func readModuleDescription(moduleDir string) (string, error) {
+	readmePath := filepath.Join(moduleDir, DefaultReadmeFile)
+	if !util.FileExists(readmePath) {
+		return "", nil
+	}
+
+	content, err := util.ReadFileAsString(readmePath)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return readmeDescription(content), nil
+}
+
+// readmeDescription returns the text of the markdown document between the title and the next heading. The lines
+// starting with `#` inside the fenced code blocks are not treated as headings.
+func readmeDescription(content string) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	// skip the blank lines before the title and the title itself
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "# ") {
+		lines = lines[1:]
+	}
+
+	var (
+		description []string
+		inCodeBlock bool
+	)
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = !inCodeBlock
+		} else if !inCodeBlock && strings.HasPrefix(trimmed, "#") {
+			break
+		}
+
+		description = append(description, line)
+	}
+
+	return strings.TrimSpace(strings.Join(description, "\n"))
+}
+
+// moduleMetadata returns the parts of the module url exposed to templates as the `moduleHost`, `moduleOrg`, `moduleRepo`,
+// `moduleSubdir` and `moduleRef` variables, e.g. `github.com`, `gruntwork-io`, `terragrunt`, `test/fixtures/inputs` and `v0.53.8`
+// for git::https://github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs?ref=v0.53.8. The org contains all the groups
+// of nested group urls, e.g. `group/subgroup`. The parts that are missing in the url, such as the host of local paths, are empty.
+func moduleMetadata(opts *options.TerragruntOptions, moduleURL string) map[string]string {
+	metadata := map[string]string{
+		"moduleHost":   "",
+		"moduleOrg":    "",
+		"moduleRepo":   "",
+		"moduleSubdir": "",
+		"moduleRef":    "",
+	}
+
+	parsedModuleURL, err := terraform.ToSourceURL(moduleURL, opts.WorkingDir)
+	if err != nil {
+		return metadata
+	}
+
+	rootSourceURL, subDir, err := terraform.SplitSourceURL(parsedModuleURL, opts.Logger)
+	if err != nil {
+		return metadata
+	}
+
+	metadata["moduleSubdir"] = strings.Trim(subDir, "/")
+	metadata["moduleRef"] = parsedModuleURL.Query().Get(refParam)
+
+	if rootSourceURL.Host == "" {
+		return metadata
+	}
+
+	metadata["moduleHost"] = rootSourceURL.Hostname()
+
+	repoPath := strings.Trim(rootSourceURL.Path, "/")
+	if idx := strings.LastIndex(repoPath, "/"); idx >= 0 {
+		metadata["moduleOrg"] = repoPath[:idx]
+		repoPath = repoPath[idx+1:]
+	}
+
+	metadata["moduleRepo"] = strings.TrimSuffix(repoPath, ".git")
+
+	return metadata
+}
+
+// parseModuleURL - parse module url and rewrite it if required
+func parseModuleURL(ctx context.Context, opts *options.TerragruntOptions, vars map[string]interface{}, moduleURL string) (string, error) {
+	moduleURL, err := expandNestedGroupURL(vars, moduleURL)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	parsedModuleURL, err := terraform.ToSourceURL(moduleURL, opts.WorkingDir)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	moduleURL = parsedModuleURL.String()
+
+	// archives are versioned by their url, e.g. https://example.com/module-v1.2.0.zip,
+	// so they are neither rewritten to git ssh nor pinned to a git ref
+	if isArchiveSourceURL(parsedModuleURL) {
+		if _, ok := vars[refVar]; ok {
+			opts.Logger.Warnf("The %s variable is ignored, since the module url %s is an archive", refVar, redactSourceURL(moduleURL))
+		}
+
+		return moduleURL, nil
+	}
+
+	// registry modules are versioned by the registry, the Ref variable is used as the version if it's not in the url
+	if parsedModuleURL.Scheme == registryScheme {
+		if ref, ok := vars[refVar]; ok && !parsedModuleURL.Query().Has(versionParam) {
+			query := parsedModuleURL.Query()
+			query.Set(versionParam, fmt.Sprintf("%v", ref))
+			parsedModuleURL.RawQuery = query.Encode()
+		}
+
+		return parsedModuleURL.String(), nil
+	}
+
+	// rewrite module url, if required
+	parsedModuleURL, err = rewriteModuleURL(opts, vars, moduleURL)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	// add ref to module url, if required
+	parsedModuleURL, err = addRefToModuleURL(ctx, opts, parsedModuleURL, vars)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	// regenerate module url with all changes
+	return parsedModuleURL.String(), nil
+}
+
+// resolveRegistryModule looks up the module of the given registry url, e.g. tfr://registry.terraform.io/org/name/aws,
+// in the registry, and returns the module url pinned to the latest version if the url has no version, the source url
+// the module is downloaded from and the deprecation notice of the module version, if the registry publishes one.
+// The other module urls are returned as is.
+func resolveRegistryModule(ctx context.Context, opts *options.TerragruntOptions, moduleURL string) (string, string, *terraform.RegistryModuleDeprecation, error) {
+	parsedModuleURL, err := url.Parse(moduleURL)
+	if err != nil || parsedModuleURL.Scheme != registryScheme {
+		return moduleURL, moduleURL, nil, nil
+	}
+
+	registryDomain := parsedModuleURL.Host
+	if registryDomain == "" {
+		registryDomain = terraform.DefaultRegistryDomain(opts)
+	}
+
+	modulePath, subDir := getter.SourceDirSubdir(parsedModuleURL.Path)
+	query := parsedModuleURL.Query()
+
+	basePath, err := terraform.GetModuleRegistryURLBasePath(ctx, opts.Logger, registryDomain)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	metadataURL, err := terraform.BuildMetadataRequestURL(registryDomain, basePath, modulePath, query.Get(versionParam))
+	if err != nil {
+		return "", "", nil, errors.New(err)
+	}
+
+	metadata, err := terraform.GetModuleRegistryMetadata(ctx, opts.Logger, *metadataURL)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	if !query.Has(versionParam) {
+		if metadata.Version == "" {
+			return "", "", nil, errors.New(RegistryModuleVersionNotFoundError(moduleURL))
+		}
+
+		opts.Logger.Infof("Using the latest version %s of the module %s", metadata.Version, moduleURL)
+
+		query.Set(versionParam, metadata.Version)
+		parsedModuleURL.RawQuery = query.Encode()
+	}
+
+	requestURL, err := terraform.BuildRequestURL(registryDomain, basePath, modulePath, query.Get(versionParam))
+	if err != nil {
+		return "", "", nil, errors.New(err)
+	}
+
+	terraformGet, err := terraform.GetTerraformGetHeader(ctx, opts.Logger, *requestURL)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	downloadURL, err := terraform.GetDownloadURLFromHeader(*requestURL, terraformGet)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	// the subdir of the registry url is combined with the subdir of the source returned by the registry
+	if subDir != "" {
+		source, sourceSubDir := getter.SourceDirSubdir(downloadURL)
+		source, sourceQuery, _ := strings.Cut(source, "?")
+
+		downloadURL = source + "//" + path.Join(sourceSubDir, subDir)
+		if sourceQuery != "" {
+			downloadURL += "?" + sourceQuery
+		}
+	}
+
+	opts.Logger.Debugf("Downloading the registry module %s from %s", moduleURL, redactSourceURL(downloadURL))
+
+	return parsedModuleURL.String(), downloadURL, metadata.Deprecation, nil
+}
+
+// checkModuleDeprecation warns about the deprecation notice of the module published by the registry, or fails with
+// `--terragrunt-scaffold-strict`, since the generated code would depend on a module which is no longer maintained.
+func checkModuleDeprecation(opts *options.TerragruntOptions, moduleURL string, deprecation *terraform.RegistryModuleDeprecation) error {
+	if deprecation == nil {
+		return nil
+	}
+
+	if opts.ScaffoldStrict {
+		return errors.New(ModuleDeprecatedError{moduleURL: moduleURL, deprecation: deprecation})
+	}
+
+	opts.Logger.Warnf("DEPRECATED: %s", moduleDeprecationNotice(moduleURL, deprecation))
+
+	return nil
+}
+
+// moduleDeprecationNotice returns the notice with the reason, the replacement and the link of the deprecation, if set.
+func moduleDeprecationNotice(moduleURL string, deprecation *terraform.RegistryModuleDeprecation) string {
+	notice := fmt.Sprintf("the module %s is deprecated", moduleURL)
+
+	if deprecation.Reason != "" {
+		notice += ": " + deprecation.Reason
+	}
+
+	if deprecation.Replacement != "" {
+		notice += fmt.Sprintf(", use %s instead", deprecation.Replacement)
+	}
+
+	if deprecation.Link != "" {
+		notice += fmt.Sprintf(", see %s", deprecation.Link)
+	}
+
+	return notice
+}
+
+// validateSourceURLScheme checks that the scheme and the forced getters of the given source url, e.g. `git::https`,
+// are supported, so that a typo fails with a clear error instead of an obscure download failure.
+// Whether the url can be actually downloaded is still decided by go-getter.
+func validateSourceURLScheme(sourceURL string) error {
+	scheme, _, found := strings.Cut(sourceURL, "://")
+	if !found {
+		return nil
+	}
+
+	for _, part := range strings.Split(scheme, "::") {
+		if !util.ListContainsElement(supportedSourceSchemes, strings.ToLower(part)) {
+			return errors.New(UnsupportedSourceSchemeError(part))
+		}
+	}
+
+	return nil
+}
+
+// isArchiveSourceURL returns true if the given source url is downloaded as an archive. The same as go-getter,
+// archives are detected by the `archive` query parameter or by the extension of the url path, e.g. `.zip` or `.tar.gz`.
+func isArchiveSourceURL(sourceURL *url.URL) bool {
+	if forcedGetter, _, found := strings.Cut(sourceURL.Scheme, "::"); found && (forcedGetter == "git" || forcedGetter == "hg") {
+		return false
+	}
+
+	if archive := sourceURL.Query().Get("archive"); archive != "" {
+		enabled, err := strconv.ParseBool(archive)
+
+		// any value other than a boolean is the archive type, e.g. `archive=zip`
+		return err != nil || enabled
+	}
+
+	// the archive extension is a part of the root source url, e.g. https://example.com/module.zip//modules/vpc
+	rootPath, _, _ := strings.Cut(sourceURL.Path, "//")
+
+	for ext := range getter.Decompressors {
+		if strings.HasSuffix(rootPath, "."+ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rewriteModuleURL rewrites module url to git ssh if required
+// github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs => git::https://github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs
+func rewriteModuleURL(opts *options.TerragruntOptions, vars map[string]interface{}, moduleURL string) (*url.URL, error) {
+	var updatedModuleURL = moduleURL
+
+	sourceURLType := sourceURLTypeHTTPS
+	if value, found := vars[sourceURLTypeVar]; found {
+		sourceURLType = fmt.Sprintf("%s", value)
+	}
+
+	// expand module url
+	parsedValue, err := parseURL(opts, moduleURL)
+	if err != nil {
+		opts.Logger.Warnf("Failed to parse module url %s", redactSourceURL(moduleURL))
+
+		parsedModuleURL, err := terraform.ToSourceURL(updatedModuleURL, opts.WorkingDir)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		return parsedModuleURL, nil
+	}
+	// try to rewrite module url if is https and is requested to be git
+	// git::https://github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs => git::ssh://git@github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs
+	if parsedValue.scheme == "https" && sourceURLType == sourceURLTypeGit {
+		host := parsedValue.host
+		// drop credentials, e.g. https://user@bitbucket.org/team/repo.git
+		if _, hostWithoutUser, found := strings.Cut(host, "@"); found {
+			host = hostWithoutUser
+		}
+		// drop https port, it has nothing to do with the ssh port
+		if hostWithoutPort, _, err := net.SplitHostPort(host); err == nil {
+			host = hostWithoutPort
+		}
+
+		sshHost, err := gitSSHHostSettings(vars, host)
+		if err != nil {
+			return nil, err
+		}
+
+		// use the `~/.ssh/config` host alias in place of the real host, so ssh picks the configured key
+		// git::https://github.com/team/repo.git => git::ssh://git@github-work/team/repo.git
+		if sshHost.alias != "" {
+			host = sshHost.alias
+		}
+
+		// the scp-like form `user@host:path` is only detected by go-getter for the `git` user and can't express a port,
+		// so the explicit ssh form is used to support custom users and ports
+		// git::https://git.example.com/team/repo.git => git::ssh://git@git.example.com:2222/team/repo.git
+		if sshHost.port != "" {
+			host = net.JoinHostPort(host, sshHost.port)
+		}
+
+		path := strings.TrimPrefix(parsedValue.path, "/")
+		updatedModuleURL = fmt.Sprintf("%sssh://%s@%s/%s", gitPrefix, sshHost.user, host, path)
+	}
+
+	// persist changes in url.URL
+	parsedModuleURL, err := terraform.ToSourceURL(updatedModuleURL, opts.WorkingDir)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return parsedModuleURL, nil
+}
+
+// expandNestedGroupURL expands the shorthand URL of a host with nested groups into a forced git URL,
+// since the go-getter shorthand detector considers only the first two path segments to be the repository.
+// gitlab.com/group/subgroup/module => git::https://gitlab.com/group/subgroup/module.git
+// gitlab.com/group/subgroup/module//modules/vpc => git::https://gitlab.com/group/subgroup/module.git//modules/vpc
+func expandNestedGroupURL(vars map[string]interface{}, moduleURL string) (string, error) {
+	shorthandURL := httpsSchemeRegex.ReplaceAllString(moduleURL, "")
+	if strings.Contains(shorthandURL, "://") || strings.Contains(shorthandURL, "::") {
+		return moduleURL, nil
+	}
+
+	host, path, found := strings.Cut(shorthandURL, "/")
+	if !found {
+		return moduleURL, nil
+	}
+
+	sshHost, err := gitSSHHostSettings(vars, host)
+	if err != nil {
+		return "", err
+	}
+
+	if sshHost.pathStyle != gitPathStyleNested {
+		return moduleURL, nil
+	}
+
+	path, query, _ := strings.Cut(path, "?")
+	repoPath, subDir, _ := strings.Cut(path, "//")
+
+	if !strings.HasSuffix(repoPath, ".git") {
+		repoPath += ".git"
+	}
+
+	expandedURL := fmt.Sprintf("%shttps://%s/%s", gitPrefix, host, repoPath)
+
+	if subDir != "" {
+		expandedURL += "//" + subDir
+	}
+
+	if query != "" {
+		expandedURL += "?" + query
+	}
+
+	return expandedURL, nil
+}
+
+// gitSSHHostSettings returns the Git/SSH rewrite settings for the given host.
+// The settings are taken from the `SourceGitSshHosts` var, which maps a host either to an ssh user or to a map with
+// `User`, `Port`, `PathStyle` and `HostAlias` keys, falling back to `SourceGitSshUser`, `SourceGitSshPort`,
+// `SourceGitSshHostAlias` and the well-known hosts defaults.
+func gitSSHHostSettings(vars map[string]interface{}, host string) (gitSSHHost, error) {
+	sshHost, found := defaultGitSSHHosts[host]
+	if !found {
+		sshHost = gitSSHHost{user: sourceGitSSHUser, pathStyle: gitPathStyleDefault}
+	}
+
+	if value, found := vars[sourceGitSSHUserVar]; found {
+		sshHost.user = fmt.Sprintf("%s", value)
+	}
+
+	if value, found := vars[sourceGitSSHPortVar]; found {
+		sshHost.port = fmt.Sprintf("%v", value)
+	}
+
+	if value, found := vars[sourceGitSSHHostAliasVar]; found {
+		sshHost.alias = fmt.Sprintf("%s", value)
+	}
+
+	value, found := vars[sourceGitSSHHostsVar]
+	if !found {
+		return sshHost, validateGitSSHHost(sshHost)
+	}
+
+	hosts, ok := value.(map[string]interface{})
+	if !ok {
+		return sshHost, errors.New(InvalidGitSSHHostsError{host: host, reason: "expected a map of hosts"})
+	}
+
+	switch hostValue := hosts[host].(type) {
+	case nil:
+	case string:
+		sshHost.user = hostValue
+	case map[string]interface{}:
+		if user, found := hostValue[gitSSHHostUserKey]; found {
+			sshHost.user = fmt.Sprintf("%s", user)
+		}
+
+		if port, found := hostValue[gitSSHHostPortKey]; found {
+			sshHost.port = fmt.Sprintf("%v", port)
+		}
+
+		if pathStyle, found := hostValue[gitSSHHostPathStyleKey]; found {
+			sshHost.pathStyle = fmt.Sprintf("%s", pathStyle)
+		}
+
+		if alias, found := hostValue[gitSSHHostAliasKey]; found {
+			sshHost.alias = fmt.Sprintf("%s", alias)
+		}
+	default:
+		return sshHost, errors.New(InvalidGitSSHHostsError{host: host, reason: "expected an ssh user or a map"})
+	}
+
+	if sshHost.pathStyle != gitPathStyleDefault && sshHost.pathStyle != gitPathStyleNested {
+		return sshHost, errors.New(InvalidGitSSHHostsError{host: host, reason: "unknown path style " + sshHost.pathStyle})
+	}
+
+	return sshHost, validateGitSSHHost(sshHost)
+}
+
+// validateGitSSHHost returns an error if the port or the host alias of the given Git/SSH settings are not valid.
+func validateGitSSHHost(sshHost gitSSHHost) error {
+	// the alias replaces only the host name, the user, port and path are still taken from the url
+	if strings.ContainsAny(sshHost.alias, "/:@ ") {
+		return errors.New(InvalidGitSSHHostAliasError(sshHost.alias))
+	}
+
+	return validateGitSSHPort(sshHost.port)
+}
+
+// validateGitSSHPort returns an error if the given non-empty port is not a valid port number.
+func validateGitSSHPort(port string) error {
+	if port == "" {
+		return nil
+	}
+
+	if num, err := strconv.Atoi(port); err != nil || num < 1 || num > maxPortNumber {
+		return errors.New(InvalidGitSSHPortError(port))
+	}
+
+	return nil
+}
+
+// rewriteTemplateURL rewrites template url with reference to tag
+// github.com/denis256/terragrunt-tests.git//scaffold/base-template => github.com/denis256/terragrunt-tests.git//scaffold/base-template?ref=v0.53.8
+func rewriteTemplateURL(ctx context.Context, opts *options.TerragruntOptions, parsedTemplateURL *url.URL, vars map[string]interface{}) (*url.URL, error) {
+	var (
+		updatedTemplateURL = parsedTemplateURL
+		templateParams     = updatedTemplateURL.Query()
+	)
 
 	ref := templateParams.Get(refParam)
 	if ref == "" {
@@ -363,21 +2648,98 @@ func rewriteTemplateURL(ctx context.Context, opts *options.TerragruntOptions, pa
 			return nil, errors.New(err)
 		}
 
-		tag, err := shell.GitLastReleaseTag(ctx, opts, rootSourceURL)
-		if err != nil || tag == "" {
-			opts.Logger.Warnf("Failed to find last release tag for URL %s, so will not add a ref param to the URL", rootSourceURL)
-		} else {
-			templateParams.Add(refParam, tag)
-			updatedTemplateURL.RawQuery = templateParams.Encode()
-		}
+		tagOpts, err := releaseTagOptions(vars)
+		if err != nil {
+			return nil, err
+		}
+
+		tag, err := shell.GitLastReleaseTag(ctx, opts, rootSourceURL, tagOpts...)
+		if err != nil || tag == "" {
+			opts.Logger.Warnf("Failed to find last release tag for URL %s, so will not add a ref param to the URL", redactSourceURL(rootSourceURL.String()))
+		} else {
+			templateParams.Add(refParam, tag)
+			updatedTemplateURL.RawQuery = templateParams.Encode()
+		}
+	}
+
+	return updatedTemplateURL, nil
+}
+
+// checkRefMismatch checks if the module and the template come from the same repository but are pinned to different refs.
+// The mismatch is logged as a warning, or returned as an error if the strict scaffold mode is enabled.
+func checkRefMismatch(opts *options.TerragruntOptions, moduleURL string, templateURL *url.URL) error {
+	parsedModuleURL, err := terraform.ToSourceURL(moduleURL, opts.WorkingDir)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	moduleRef, templateRef := parsedModuleURL.Query().Get(refParam), templateURL.Query().Get(refParam)
+	if moduleRef == "" || templateRef == "" || moduleRef == templateRef {
+		return nil
+	}
+
+	moduleRepo, err := repoID(opts, parsedModuleURL)
+	if err != nil {
+		return err
+	}
+
+	templateRepo, err := repoID(opts, templateURL)
+	if err != nil {
+		return err
+	}
+
+	if moduleRepo != templateRepo {
+		return nil
+	}
+
+	mismatchErr := RefMismatchError{repo: moduleRepo, moduleRef: moduleRef, templateRef: templateRef}
+
+	if opts.ScaffoldStrict {
+		return errors.New(mismatchErr)
+	}
+
+	opts.Logger.Warn(mismatchErr.Error())
+
+	return nil
+}
+
+// repoID returns the key of the repository of the given source URL, regardless of the scheme, user and ref, see `terraform.NormalizeSourceURL`.
+// git::ssh://git@github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs?ref=v0.53.8 => github.com/gruntwork-io/terragrunt
+func repoID(opts *options.TerragruntOptions, sourceURL *url.URL) (string, error) {
+	rootSourceURL, _, err := terraform.SplitSourceURL(sourceURL, opts.Logger)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	repo, err := terraform.NormalizeSourceURL(rootSourceURL.String(), opts.WorkingDir)
+	if err != nil {
+		return "", errors.New(err)
 	}
 
-	return updatedTemplateURL, nil
+	return repo, nil
 }
 
 // addRefToModuleURL adds ref to module url if is passed through variables or find it from git tags
 func addRefToModuleURL(ctx context.Context, opts *options.TerragruntOptions, parsedModuleURL *url.URL, vars map[string]interface{}) (*url.URL, error) {
 	var moduleURL = parsedModuleURL
+
+	// objects of S3 and GCS buckets are versioned by their url, there is no git repository to look up the tags
+	if isObjectStoreSourceURL(moduleURL) {
+		if _, ok := vars[refVar]; ok {
+			opts.Logger.Warnf("The %s variable is ignored, since the module url %s is an object store source", refVar, redactSourceURL(moduleURL.String()))
+		}
+
+		return moduleURL, nil
+	}
+
+	if opts.ScaffoldNoRef {
+		if _, ok := vars[refVar]; ok {
+			opts.Logger.Warnf("The %s variable is ignored, since --%s is set", refVar, FlagNameTerragruntScaffoldNoRef)
+		}
+
+		return moduleURL, nil
+	}
+
 	// append ref to source url, if is passed through variables or find it from git tags
 	params := moduleURL.Query()
 
@@ -388,6 +2750,28 @@ func addRefToModuleURL(ctx context.Context, opts *options.TerragruntOptions, par
 	}
 
 	ref := params.Get(refParam)
+	if ref == "" {
+		// the ref passed explicitly takes precedence over the versions file
+		lockedRef, err := lockedModuleRef(opts, moduleURL)
+		if err != nil {
+			return nil, err
+		}
+
+		if lockedRef != "" {
+			opts.Logger.Debugf("Using ref %s of %s from %s", lockedRef, redactSourceURL(moduleURL.String()), opts.ScaffoldVersionsFile)
+
+			ref = lockedRef
+			params.Set(refParam, ref)
+			moduleURL.RawQuery = params.Encode()
+		}
+	}
+
+	if opts.ScaffoldVerifyRef && commitSHARegex.MatchString(ref) {
+		if err := verifyCommitRef(ctx, opts, moduleURL, ref); err != nil {
+			return nil, err
+		}
+	}
+
 	if ref == "" {
 		// if ref is not passed, find last release tag
 		// git::https://github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs => git::https://github.com/gruntwork-io/terragrunt.git//test/fixtures/inputs?ref=v0.53.8
@@ -396,23 +2780,223 @@ func addRefToModuleURL(ctx context.Context, opts *options.TerragruntOptions, par
 			return nil, errors.New(err)
 		}
 
-		tag, err := shell.GitLastReleaseTag(ctx, opts, rootSourceURL)
+		tagOpts, err := releaseTagOptions(vars)
+		if err != nil {
+			return nil, err
+		}
+
+		tag, err := shell.GitLastReleaseTag(ctx, opts, rootSourceURL, tagOpts...)
 		if err != nil || tag == "" {
-			opts.Logger.Warnf("Failed to find last release tag for %s", rootSourceURL)
+			opts.Logger.Warnf("Failed to find last release tag for %s", redactSourceURL(rootSourceURL.String()))
 		} else {
 			params.Add(refParam, tag)
 			moduleURL.RawQuery = params.Encode()
+
+			if err := checkStaleRef(ctx, opts, vars, rootSourceURL, tag); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	return moduleURL, nil
 }
 
+// lockedModuleRef returns the ref the module is pinned to in the versions file set with `--terragrunt-scaffold-versions-file`,
+// or an empty string if the file is not set or has no entry for the module. The file maps the source URLs to the refs,
+// the URLs are compared normalized, see `terraform.NormalizeSourceURL`, and an entry of the module path takes precedence
+// over the entry of its repository:
+//
+//	github.com/gruntwork-io/terragrunt: v0.53.8
+//	"git::https://github.com/org/modules.git//vpc": v1.2.0
+func lockedModuleRef(opts *options.TerragruntOptions, moduleURL *url.URL) (string, error) {
+	if opts.ScaffoldVersionsFile == "" {
+		return "", nil
+	}
+
+	versionsFile := opts.ScaffoldVersionsFile
+	if !filepath.IsAbs(versionsFile) {
+		versionsFile = filepath.Join(opts.WorkingDir, versionsFile)
+	}
+
+	if !util.FileExists(versionsFile) {
+		return "", errors.New(VersionsFileNotFoundError(versionsFile))
+	}
+
+	entries, err := variables.ParseVars(nil, []string{versionsFile})
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	refs := make(map[string]string, len(entries))
+
+	for source, ref := range entries {
+		key, err := terraform.NormalizeSourceURL(source, opts.WorkingDir)
+		if err != nil {
+			return "", errors.New(InvalidVersionsFileEntryError{file: versionsFile, source: source, err: err})
+		}
+
+		refs[key] = fmt.Sprintf("%v", ref)
+	}
+
+	moduleID, err := terraform.NormalizeSourceURL(moduleURL.String(), opts.WorkingDir)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	if ref, ok := refs[moduleID]; ok {
+		return ref, nil
+	}
+
+	moduleRepo, err := repoID(opts, moduleURL)
+	if err != nil {
+		return "", err
+	}
+
+	return refs[moduleRepo], nil
+}
+
+// checkStaleRef warns if the given release tag of the module is older than the number of days set by the `StaleRefDays`
+// variable, since a newer release may exist under a different tag prefix or major version. The check is disabled by default,
+// and failing to find out the tag date doesn't prevent scaffolding.
+func checkStaleRef(ctx context.Context, opts *options.TerragruntOptions, vars map[string]interface{}, rootSourceURL *url.URL, tag string) error {
+	staleRefDays, err := intVar(vars, staleRefDaysVar)
+	if err != nil || staleRefDays == 0 {
+		return err
+	}
+
+	tagDate, err := shell.GitTagDate(ctx, opts, rootSourceURL, tag)
+	if err != nil {
+		opts.Logger.Debugf("Failed to find the date of tag %s in %s: %v", tag, redactSourceURL(rootSourceURL.String()), err)
+		return nil
+	}
+
+	if age := int(time.Since(tagDate).Hours() / hoursPerDay); age > staleRefDays {
+		opts.Logger.Warnf("The last release tag %s of %s was created %d days ago, the module may be stale. Check if there is a newer release.", tag, redactSourceURL(rootSourceURL.String()), age)
+	}
+
+	return nil
+}
+
+// verifyCommitRef checks that the commit SHA the module url is pinned to exists in the module repository.
+func verifyCommitRef(ctx context.Context, opts *options.TerragruntOptions, moduleURL *url.URL, sha string) error {
+	rootSourceURL, _, err := terraform.SplitSourceURL(moduleURL, opts.Logger)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	// the ref is a part of the root source url query, and has to be removed so that git can query the repository
+	repoURL := *rootSourceURL
+	repoURL.RawQuery = ""
+
+	opts.Logger.Debugf("Verifying that commit %s exists in %s", sha, redactSourceURL(repoURL.String()))
+
+	exists, err := shell.GitCommitExists(ctx, opts, &repoURL, sha)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return errors.New(CommitNotFoundError{sha: sha, repo: repoURL.String()})
+	}
+
+	return nil
+}
+
+// releaseTagOptions returns the options of the last release tag lookup set through variables.
+// The prerelease tags are filtered only if `AllowPrerelease` is passed, otherwise all semver tags are considered.
+func releaseTagOptions(vars map[string]interface{}) ([]shell.ReleaseTagOption, error) {
+	var tagOpts []shell.ReleaseTagOption
+
+	if _, found := vars[allowPrereleaseVar]; found {
+		allowPrerelease, err := boolVar(vars, allowPrereleaseVar)
+		if err != nil {
+			return nil, err
+		}
+
+		tagOpts = append(tagOpts, shell.WithPrerelease(allowPrerelease))
+	}
+
+	if tagPrefix, found := vars[tagPrefixVar]; found {
+		tagOpts = append(tagOpts, shell.WithTagPrefix(fmt.Sprintf("%v", tagPrefix)))
+	}
+
+	return tagOpts, nil
+}
+
+// boolVar returns the value of the given boolean variable, false if the variable is not passed.
+func boolVar(vars map[string]interface{}, name string) (bool, error) {
+	value, found := vars[name]
+	if !found {
+		return false, nil
+	}
+
+	if value, ok := value.(bool); ok {
+		return value, nil
+	}
+
+	parsed, err := strconv.ParseBool(fmt.Sprintf("%v", value))
+	if err != nil {
+		return false, errors.New(InvalidVarError{name: name, value: value, expected: "true or false"})
+	}
+
+	return parsed, nil
+}
+
+// templateSourceURL returns the module url rendered as the `source` of the generated config. The ref is resolved and
+// pinned only for the downloaded module url, so `--terragrunt-scaffold-source-override` is rendered as is.
+func templateSourceURL(opts *options.TerragruntOptions, moduleURL string) string {
+	if opts.ScaffoldSourceOverride == "" {
+		return moduleURL
+	}
+
+	opts.Logger.Debugf("Using source %s instead of %s", redactSourceURL(opts.ScaffoldSourceOverride), redactSourceURL(moduleURL))
+
+	return opts.ScaffoldSourceOverride
+}
+
+// placeholders returns the placeholders of the required variables, passed with the `placeholders` map variable,
+// e.g. in a var file, and with `--terragrunt-scaffold-placeholder`, which takes precedence.
+func placeholders(opts *options.TerragruntOptions, vars map[string]interface{}) (map[string]string, error) {
+	result := make(map[string]string, len(opts.ScaffoldPlaceholders))
+
+	if value, found := vars[placeholdersVar]; found {
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.New(InvalidVarError{name: placeholdersVar, value: value, expected: "a map of variable names to placeholders"})
+		}
+
+		for name, placeholder := range values {
+			result[name] = fmt.Sprintf("%v", placeholder)
+		}
+	}
+
+	for name, placeholder := range opts.ScaffoldPlaceholders {
+		result[name] = placeholder
+	}
+
+	return result, nil
+}
+
+// intVar returns the value of the given non-negative integer variable, 0 if the variable is not passed.
+func intVar(vars map[string]interface{}, name string) (int, error) {
+	value, found := vars[name]
+	if !found {
+		return 0, nil
+	}
+
+	parsed, err := strconv.Atoi(fmt.Sprintf("%v", value))
+	if err != nil || parsed < 0 {
+		return 0, errors.New(InvalidVarError{name: name, value: value, expected: "a non-negative number"})
+	}
+
+	return parsed, nil
+}
+
 // parseURL parses module url to scheme, host and path
 func parseURL(opts *options.TerragruntOptions, moduleURL string) (*parsedURL, error) {
 	matches := moduleURLRegex.FindStringSubmatch(moduleURL)
 	if len(matches) != moduleURLParts {
-		opts.Logger.Warnf("Failed to parse url %s", moduleURL)
+		opts.Logger.Warnf("Failed to parse url %s", redactSourceURL(moduleURL))
 		return nil, failedToParseURLError{}
 	}
 
@@ -429,6 +3013,14 @@ type parsedURL struct {
 	path   string
 }
 
+// gitSSHHost contains the settings used to rewrite an https git URL of a host to the Git/SSH form.
+type gitSSHHost struct {
+	user      string
+	port      string
+	pathStyle string
+	alias     string
+}
+
 type failedToParseURLError struct {
 }
 
@@ -442,3 +3034,275 @@ type NoModuleURLPassed struct {
 func (err NoModuleURLPassed) Error() string {
 	return "No module URL passed."
 }
+
+type InvalidGitSSHHostsError struct {
+	host   string
+	reason string
+}
+
+func (err InvalidGitSSHHostsError) Error() string {
+	return fmt.Sprintf("Invalid %s value for host %s: %s.", sourceGitSSHHostsVar, err.host, err.reason)
+}
+
+type InvalidGitSSHPortError string
+
+func (err InvalidGitSSHPortError) Error() string {
+	return fmt.Sprintf("Invalid Git/SSH port %s.", string(err))
+}
+
+type InvalidGitSSHHostAliasError string
+
+func (err InvalidGitSSHHostAliasError) Error() string {
+	return fmt.Sprintf("Invalid Git/SSH host alias %s.", string(err))
+}
+
+type InvalidVarError struct {
+	value    interface{}
+	name     string
+	expected string
+}
+
+func (err InvalidVarError) Error() string {
+	return fmt.Sprintf("Invalid value %v of %s variable, expected %s.", err.value, err.name, err.expected)
+}
+
+type VarFileNotFoundError string
+
+func (err VarFileNotFoundError) Error() string {
+	return fmt.Sprintf("Var file not found at %s.", string(err))
+}
+
+type ParseVariablesError struct {
+	err error
+}
+
+func (err ParseVariablesError) Error() string {
+	return fmt.Sprintf("Failed to parse input variables of the module: %v", err.err)
+}
+
+func (err ParseVariablesError) Unwrap() error {
+	return err.err
+}
+
+type BoilerplateConfigNotFoundError string
+
+func (err BoilerplateConfigNotFoundError) Error() string {
+	return fmt.Sprintf("Boilerplate config %s is not found in the template.", string(err))
+}
+
+type CommitNotFoundError struct {
+	sha  string
+	repo string
+}
+
+func (err CommitNotFoundError) Error() string {
+	return fmt.Sprintf("Commit %s is not found in the repository %s.", err.sha, err.repo)
+}
+
+type RefMismatchError struct {
+	repo        string
+	moduleRef   string
+	templateRef string
+}
+
+func (err RefMismatchError) Error() string {
+	return fmt.Sprintf("The module and the template from the repository %s are pinned to different refs: %s and %s.", err.repo, err.moduleRef, err.templateRef)
+}
+
+type InvalidMatrixError string
+
+func (err InvalidMatrixError) Error() string {
+	return fmt.Sprintf("Invalid value %q of --%s, expected <name>=<value>[,<value>...] with the values usable as directory names.", string(err), FlagNameTerragruntScaffoldMatrix)
+}
+
+type ConflictingFlagsError [2]string
+
+func (err ConflictingFlagsError) Error() string {
+	return fmt.Sprintf("The --%s and --%s flags can't be used together.", err[0], err[1])
+}
+
+type StageManifestNotFoundError string
+
+func (err StageManifestNotFoundError) Error() string {
+	return fmt.Sprintf("The manifest %s is not found in %s, stage the files with --%s first.", StageManifestFile, string(err), FlagNameTerragruntScaffoldStageDir)
+}
+
+type StagedModuleMismatchError struct {
+	stageDir string
+	staged   string
+	passed   string
+}
+
+func (err StagedModuleMismatchError) Error() string {
+	return fmt.Sprintf("The files in %s were staged for the module %s, not %s.", err.stageDir, redactSourceURL(err.staged), redactSourceURL(err.passed))
+}
+
+type InvalidExcludeGlobError string
+
+func (err InvalidExcludeGlobError) Error() string {
+	return fmt.Sprintf("Invalid glob %q of --%s.", string(err), FlagNameTerragruntScaffoldExclude)
+}
+
+type MatrixValueError struct {
+	err   error
+	value string
+}
+
+func (err MatrixValueError) Error() string {
+	return fmt.Sprintf("Failed to scaffold the matrix value %s: %v", err.value, err.err)
+}
+
+func (err MatrixValueError) Unwrap() error {
+	return err.err
+}
+
+type UnsupportedSourceSchemeError string
+
+func (err UnsupportedSourceSchemeError) Error() string {
+	return fmt.Sprintf("Unsupported scheme %s of the module url, supported schemes: %s.", string(err), strings.Join(supportedSourceSchemes, ", "))
+}
+
+type UnknownTemplatePresetError string
+
+func (err UnknownTemplatePresetError) Error() string {
+	return fmt.Sprintf("Unknown template preset %s, available presets: %s.", string(err), strings.Join(templatePresetNames, ", "))
+}
+
+type ModifiedFilesOverwriteError []string
+
+func (err ModifiedFilesOverwriteError) Error() string {
+	return fmt.Sprintf("The generated files would overwrite the files with uncommitted changes: %s. Commit or stash the changes first.", strings.Join(err, ", "))
+}
+
+type VersionsFileNotFoundError string
+
+func (err VersionsFileNotFoundError) Error() string {
+	return fmt.Sprintf("The versions file %s set with --%s is not found.", string(err), FlagNameTerragruntScaffoldVersions)
+}
+
+type InvalidVersionsFileEntryError struct {
+	err    error
+	file   string
+	source string
+}
+
+func (err InvalidVersionsFileEntryError) Error() string {
+	return fmt.Sprintf("Invalid source URL %s in the versions file %s: %v", redactSourceURL(err.source), err.file, err.err)
+}
+
+func (err InvalidVersionsFileEntryError) Unwrap() error {
+	return err.err
+}
+
+type InvalidGeneratedFileError struct {
+	err  error
+	file string
+}
+
+func (err InvalidGeneratedFileError) Error() string {
+	return fmt.Sprintf("The generated file %s is invalid, check the template: %v", err.file, err.err)
+}
+
+func (err InvalidGeneratedFileError) Unwrap() error {
+	return err.err
+}
+
+type EmptyStdinTemplateError struct{}
+
+func (err EmptyStdinTemplateError) Error() string {
+	return "The template read from stdin is empty."
+}
+
+type StdinTemplateConflictError string
+
+func (err StdinTemplateConflictError) Error() string {
+	return fmt.Sprintf("The template url %s can't be used with --%s, the template is read from stdin.", redactSourceURL(string(err)), FlagNameTerragruntScaffoldFromStdin)
+}
+
+type InvalidStackManifestError struct {
+	file   string
+	reason string
+}
+
+func (err InvalidStackManifestError) Error() string {
+	return fmt.Sprintf("Invalid stack manifest %s: %s.", err.file, err.reason)
+}
+
+type StackDependencyCycleError []string
+
+func (err StackDependencyCycleError) Error() string {
+	return "The units of the stack manifest depend on each other: " + strings.Join(err, " -> ") + "."
+}
+
+type StackManifestConflictError string
+
+func (err StackManifestConflictError) Error() string {
+	return fmt.Sprintf("The templates with the %s stack manifest can't be used with --%s.", StackManifestFile, string(err))
+}
+
+type StackUnitError struct {
+	unit string
+	err  error
+}
+
+func (err StackUnitError) Error() string {
+	return fmt.Sprintf("Failed to scaffold unit %s: %v", err.unit, err.err)
+}
+
+func (err StackUnitError) Unwrap() error {
+	return err.err
+}
+
+type RegistryModuleVersionNotFoundError string
+
+func (err RegistryModuleVersionNotFoundError) Error() string {
+	return fmt.Sprintf("The registry returned no version of the module %s, pass the version with ?%s=<version> or the %s variable.", string(err), versionParam, refVar)
+}
+
+type ModuleDeprecatedError struct {
+	moduleURL   string
+	deprecation *terraform.RegistryModuleDeprecation
+}
+
+func (err ModuleDeprecatedError) Error() string {
+	return fmt.Sprintf("Failed to scaffold, %s.", moduleDeprecationNotice(err.moduleURL, err.deprecation))
+}
+
+type IncludeDirNotFoundError string
+
+func (err IncludeDirNotFoundError) Error() string {
+	return fmt.Sprintf("The directory %s of --%s is not found.", string(err), FlagNameTerragruntScaffoldIncludeDir)
+}
+
+type LockFileNotFoundError string
+
+func (err LockFileNotFoundError) Error() string {
+	return fmt.Sprintf("The lock %s is not found in %s, scaffold the files without --%s first.", LockFile, string(err), FlagNameTerragruntScaffoldVerify)
+}
+
+type LockMismatchError struct {
+	dir    string
+	fields []string
+}
+
+func (err LockMismatchError) Error() string {
+	return fmt.Sprintf("The files in %s do not match %s, the resolved %s differ.", err.dir, LockFile, strings.Join(err.fields, ", "))
+}
+
+type InvalidVarJSONError struct {
+	name  string
+	value string
+	err   error
+}
+
+func (err InvalidVarJSONError) Error() string {
+	if err.name == "" {
+		return fmt.Sprintf("Invalid value %s of --%s: %v.", err.value, FlagNameTerragruntScaffoldVarJSON, err.err)
+	}
+
+	return fmt.Sprintf("Invalid JSON value %s of %s variable passed with --%s: %v.", err.value, err.name, FlagNameTerragruntScaffoldVarJSON, err.err)
+}
+
+func (err InvalidVarJSONError) Unwrap() error {
+	return err.err
+}
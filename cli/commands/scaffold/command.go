@@ -10,6 +10,31 @@ const (
 	CommandName = "scaffold"
 	Var         = "var"
 	VarFile     = "var-file"
+
+	FlagNameTerragruntScaffoldStrict      = "terragrunt-scaffold-strict"
+	FlagNameTerragruntScaffoldVerifyRef   = "terragrunt-scaffold-verify-ref"
+	FlagNameTerragruntScaffoldConfig      = "terragrunt-scaffold-config-file"
+	FlagNameTerragruntScaffoldTemplate    = "terragrunt-scaffold-template-file"
+	FlagNameTerragruntScaffoldPreset      = "terragrunt-scaffold-template-preset"
+	FlagNameTerragruntScaffoldPostHook    = "terragrunt-scaffold-post-hook"
+	FlagNameTerragruntScaffoldNoRef       = "terragrunt-scaffold-no-ref"
+	FlagNameTerragruntScaffoldAuth        = "terragrunt-scaffold-auth-header"
+	FlagNameTerragruntScaffoldKeepTemp    = "terragrunt-scaffold-keep-temp"
+	FlagNameTerragruntScaffoldRespectGit  = "terragrunt-scaffold-respect-git"
+	FlagNameTerragruntScaffoldPlaceholder = "terragrunt-scaffold-placeholder"
+	FlagNameTerragruntScaffoldSource      = "terragrunt-scaffold-source-override"
+	FlagNameTerragruntScaffoldMatrix      = "terragrunt-scaffold-matrix"
+	FlagNameTerragruntScaffoldStrictParse = "terragrunt-scaffold-strict-parse"
+	FlagNameTerragruntScaffoldExclude     = "terragrunt-scaffold-exclude"
+	FlagNameTerragruntScaffoldStageDir    = "terragrunt-scaffold-stage-dir"
+	FlagNameTerragruntScaffoldApplyFrom   = "terragrunt-scaffold-apply-from"
+	FlagNameTerragruntScaffoldVersions    = "terragrunt-scaffold-versions-file"
+	FlagNameTerragruntScaffoldFromStdin   = "terragrunt-scaffold-from-stdin"
+	FlagNameTerragruntScaffoldIncludeDir  = "terragrunt-scaffold-include-dir"
+	FlagNameTerragruntScaffoldS3RoleARN   = "terragrunt-scaffold-s3-role-arn"
+	FlagNameTerragruntScaffoldVerify      = "terragrunt-scaffold-verify"
+	FlagNameTerragruntScaffoldVarJSON     = "terragrunt-scaffold-var-json"
+	FlagNameTerragruntScaffoldGCSAccount  = "terragrunt-scaffold-gcs-service-account"
 )
 
 func NewFlags(opts *options.TerragruntOptions) cli.Flags {
@@ -19,10 +44,154 @@ func NewFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.ScaffoldVars,
 			Usage:       "Variables for usage in scaffolding.",
 		},
+		// the flag has no environment variable, since TERRAGRUNT_SCAFFOLD_VAR_<name> variables are the scaffold variables
+		&cli.SliceFlag[string]{
+			Name:        FlagNameTerragruntScaffoldVarJSON,
+			Destination: &opts.ScaffoldVarsJSON,
+			Usage:       "Variables with JSON values, such as lists and maps, for usage in scaffolding, e.g. tags='{\"team\":\"platform\"}'.",
+		},
 		&cli.SliceFlag[string]{
 			Name:        VarFile,
 			Destination: &opts.ScaffoldVarFiles,
-			Usage:       "Files with variables to be used in modules scaffolding.",
+			Usage:       "Files with variables to be used in modules scaffolding, can be local paths or go-getter URLs.",
+		},
+		&cli.BoolFlag{
+			Name:        FlagNameTerragruntScaffoldStrict,
+			Destination: &opts.ScaffoldStrict,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_STRICT",
+			Usage:       "Fail scaffolding if the module and the template refer to the same repository with different refs, or the registry module is deprecated.",
+		},
+		&cli.BoolFlag{
+			Name:        FlagNameTerragruntScaffoldVerify,
+			Destination: &opts.ScaffoldVerify,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_VERIFY",
+			Usage:       "Re-resolve the module and the template and verify that they match the " + LockFile + " lock in the working directory, instead of scaffolding.",
+		},
+		&cli.BoolFlag{
+			Name:        FlagNameTerragruntScaffoldVerifyRef,
+			Destination: &opts.ScaffoldVerifyRef,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_VERIFY_REF",
+			Usage:       "Verify that the commit SHA the module is pinned to exists in the module repository.",
+		},
+		&cli.BoolFlag{
+			Name:        FlagNameTerragruntScaffoldFromStdin,
+			Destination: &opts.ScaffoldTemplateFromStdin,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_FROM_STDIN",
+			Usage:       "Read a single file template of the generated config from stdin, the same as passing - as the template url.",
+		},
+		&cli.BoolFlag{
+			Name:        FlagNameTerragruntScaffoldNoRef,
+			Destination: &opts.ScaffoldNoRef,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_NO_REF",
+			Usage:       "Do not pin the module to the Ref variable or the last release tag, the module url is used as is.",
+		},
+		&cli.BoolFlag{
+			Name:        FlagNameTerragruntScaffoldKeepTemp,
+			Destination: &opts.ScaffoldKeepTemp,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_KEEP_TEMP",
+			Usage:       "Keep the temporary directories with the downloaded module and the boilerplate files, and log their paths.",
+		},
+		&cli.BoolFlag{
+			Name:        FlagNameTerragruntScaffoldRespectGit,
+			Destination: &opts.ScaffoldRespectGit,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_RESPECT_GIT",
+			Usage:       "Fail scaffolding if the generated files would overwrite tracked files with uncommitted changes in the working directory.",
+		},
+		&cli.MapFlag[string, string]{
+			Name:        FlagNameTerragruntScaffoldPlaceholder,
+			Destination: &opts.ScaffoldPlaceholders,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_PLACEHOLDER",
+			Usage:       "A name=value placeholder rendered in place of the TODO of the required variable with the given name. May be specified multiple times.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldSource,
+			Destination: &opts.ScaffoldSourceOverride,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_SOURCE_OVERRIDE",
+			Usage:       "The module url rendered as the source of the generated config, the module is still downloaded from the passed url.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldMatrix,
+			Destination: &opts.ScaffoldMatrix,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_MATRIX",
+			Usage:       "A name=value1,value2 variable, the module is scaffolded for each value to the subdirectory named by the value.",
+		},
+		&cli.BoolFlag{
+			Name:        FlagNameTerragruntScaffoldStrictParse,
+			Destination: &opts.ScaffoldStrictParse,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_STRICT_PARSE",
+			Usage:       "Fail scaffolding if any tf file of the module fails to parse. Set to false to skip such files with a warning. Enabled by default.",
+		},
+		&cli.SliceFlag[string]{
+			Name:        FlagNameTerragruntScaffoldExclude,
+			Destination: &opts.ScaffoldExclude,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_EXCLUDE",
+			Usage:       "A glob of the generated files which are not written to the working directory, e.g. '**/README.md'. May be specified multiple times.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldStageDir,
+			Destination: &opts.ScaffoldStageDir,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_STAGE_DIR",
+			Usage:       "Generate the files with a manifest to the given directory for review, instead of the working directory.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldApplyFrom,
+			Destination: &opts.ScaffoldApplyFrom,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_APPLY_FROM",
+			Usage:       "Copy the files staged with --" + FlagNameTerragruntScaffoldStageDir + " from the given directory to the working directory.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldVersions,
+			Destination: &opts.ScaffoldVersionsFile,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_VERSIONS_FILE",
+			Usage:       "YAML file mapping the module source URLs to the refs they are pinned to, used instead of the last release tag if the Ref variable is not set.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldIncludeDir,
+			Destination: &opts.ScaffoldIncludeDir,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_INCLUDE_DIR",
+			Usage:       "Local directory whose files are copied as is, without rendering, to the scaffolded directory after the template is rendered.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldConfig,
+			Destination: &opts.ScaffoldConfigFile,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_CONFIG_FILE",
+			Usage:       "The name of the boilerplate config file in the template, by default boilerplate.yml or boilerplate.yaml.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldTemplate,
+			Destination: &opts.ScaffoldTemplateFile,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_TEMPLATE_FILE",
+			Usage:       "The name of the generated Terragrunt config, by default terragrunt.hcl. Custom templates can use it as {{ .configFile }} in their file names.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldPreset,
+			Destination: &opts.ScaffoldTemplatePreset,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_TEMPLATE_PRESET",
+			Usage:       "The name of the built-in template used when the module has no boilerplate template: minimal or standard, by default minimal.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldPostHook,
+			Destination: &opts.ScaffoldPostHook,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_POST_HOOK",
+			Usage:       "Command to run in the working directory after the files are generated, e.g. \"terragrunt init\".",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldAuth,
+			Destination: &opts.ScaffoldAuthHeader,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_AUTH_HEADER",
+			Usage:       "Value of the Authorization header sent with HTTP(S) downloads of the module, template and var files, e.g. \"Bearer <token>\".",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldS3RoleARN,
+			Destination: &opts.ScaffoldS3RoleARN,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_S3_ROLE_ARN",
+			Usage:       "ARN of the IAM role assumed to download the module, template and var files from S3, the default credential chain is used if not set.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        FlagNameTerragruntScaffoldGCSAccount,
+			Destination: &opts.ScaffoldGCSServiceAccount,
+			EnvVar:      "TERRAGRUNT_SCAFFOLD_GCS_SERVICE_ACCOUNT",
+			Usage:       "Email of the service account impersonated to download the module, template and var files from GCS, the application default credentials are used if not set.",
 		},
 	}
 }
@@ -0,0 +1,37 @@
+package scaffold
+
+var (
+	AddRefToModuleURL       = addRefToModuleURL
+	CheckGitModifiedFiles   = checkGitModifiedFiles
+	CheckModuleDeprecation  = checkModuleDeprecation
+	CheckRefMismatch        = checkRefMismatch
+	ExpandNestedGroupURL    = expandNestedGroupURL
+	FindRootConfig          = findRootConfig
+	Generate                = generate
+	GenerateMatrixValue     = generateMatrixValue
+	GenerateStack           = generateStack
+	GetAny                  = getAny
+	IsArchiveSourceURL      = isArchiveSourceURL
+	MinimumRequiredVersion  = minimumRequiredVersion
+	ModuleMetadata          = moduleMetadata
+	ParseMatrix             = parseMatrix
+	ParseModuleURL          = parseModuleURL
+	ParseS3URL              = parseS3URL
+	ParseScaffoldVars       = parseScaffoldVars
+	ParseVariables          = parseVariables
+	Placeholders            = placeholders
+	PrepareBoilerplateFiles = prepareBoilerplateFiles
+	PrepareVarFiles         = prepareVarFiles
+	ReadModuleDescription   = readModuleDescription
+	ReadStackManifest       = readStackManifest
+	RedactSourceURL         = redactSourceURL
+	RemoveExcludedFiles     = removeExcludedFiles
+	RewriteModuleURL        = rewriteModuleURL
+	RewriteTemplateURL      = rewriteTemplateURL
+	RunPostHook             = runPostHook
+	TemplateSourceURL       = templateSourceURL
+	ValidateGeneratedFiles  = validateGeneratedFiles
+	ValidateSourceURLScheme = validateSourceURLScheme
+	WriteStageManifest      = writeStageManifest
+	WriteStdinTemplate      = writeStdinTemplate
+)
@@ -0,0 +1,199 @@
+package scaffold
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/go-commons/version"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/terraform"
+)
+
+// LockFile is written to the working directory with what was scaffolded, so that the generation can be verified
+// with `--terragrunt-scaffold-verify`.
+const LockFile = ".terragrunt-scaffold.lock"
+
+// scaffoldLock records the resolved sources and the inputs the files were scaffolded from.
+type scaffoldLock struct {
+	// ModuleURL is the module url with the resolved ref, the credentials are masked.
+	ModuleURL string `json:"module_url"`
+	// Ref is the resolved ref or the registry version of the module, empty if the module is not pinned.
+	Ref string `json:"ref,omitempty"`
+	// Commit is the commit the ref of the git module points to.
+	Commit string `json:"commit,omitempty"`
+	// TemplateURL is the template url with the resolved ref, the credentials are masked.
+	TemplateURL string `json:"template_url,omitempty"`
+	// TemplateRef is the resolved ref of the template.
+	TemplateRef string `json:"template_ref,omitempty"`
+	// TerragruntVersion is the version of Terragrunt the files were scaffolded with.
+	TerragruntVersion string `json:"terragrunt_version"`
+	// InputsHash is the SHA256 hash of the variables, the placeholders, the matrix and the template preset.
+	InputsHash string `json:"inputs_hash"`
+}
+
+// newScaffoldLock returns the lock of the given resolved module and template urls and the variables passed to the command.
+// If the commit of the git module can't be resolved, it's logged and left empty.
+func newScaffoldLock(ctx context.Context, opts *options.TerragruntOptions, vars map[string]interface{}, moduleURL, templateURL string) *scaffoldLock {
+	lock := &scaffoldLock{
+		ModuleURL:         redactSourceURL(moduleURL),
+		TemplateURL:       redactSourceURL(templateURL),
+		TerragruntVersion: version.GetVersion(),
+		InputsHash:        scaffoldInputsHash(opts, vars),
+	}
+
+	if parsedModuleURL, err := url.Parse(moduleURL); err == nil {
+		lock.Ref = parsedModuleURL.Query().Get(refParam)
+
+		if parsedModuleURL.Scheme == registryScheme {
+			lock.Ref = parsedModuleURL.Query().Get(versionParam)
+		}
+	}
+
+	if parsedTemplateURL, err := url.Parse(templateURL); err == nil && templateURL != "" {
+		lock.TemplateRef = parsedTemplateURL.Query().Get(refParam)
+	}
+
+	commit, err := gitRefCommit(ctx, opts, moduleURL, lock.Ref)
+	if err != nil {
+		opts.Logger.Warnf("Failed to resolve the commit of ref %s of %s: %v", lock.Ref, lock.ModuleURL, err)
+	}
+
+	lock.Commit = commit
+
+	return lock
+}
+
+// gitRefCommit returns the commit the ref of the git module points to, empty for the other sources and unpinned modules.
+func gitRefCommit(ctx context.Context, opts *options.TerragruntOptions, moduleURL, ref string) (string, error) {
+	if ref == "" {
+		return "", nil
+	}
+
+	parsedModuleURL, err := terraform.ToSourceURL(moduleURL, opts.WorkingDir)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	if forcedGetter, _, _ := strings.Cut(parsedModuleURL.Scheme, "::"); forcedGetter != "git" {
+		return "", nil
+	}
+
+	if commitSHARegex.MatchString(ref) {
+		return strings.ToLower(ref), nil
+	}
+
+	rootSourceURL, _, err := terraform.SplitSourceURL(parsedModuleURL, opts.Logger)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	// the ref is not a part of the repository url
+	query := rootSourceURL.Query()
+	query.Del(refParam)
+	rootSourceURL.RawQuery = query.Encode()
+
+	return shell.GitRefCommit(ctx, opts, rootSourceURL, ref)
+}
+
+// scaffoldInputsHash returns the SHA256 hash of the inputs of the command. The maps are printed with the sorted keys,
+// so the hash does not depend on the order the variables are passed in.
+func scaffoldInputsHash(opts *options.TerragruntOptions, vars map[string]interface{}) string {
+	inputs := fmt.Sprintf("%#v", struct {
+		Vars           map[string]interface{}
+		Placeholders   map[string]string
+		Matrix         string
+		TemplatePreset string
+	}{
+		Vars:           vars,
+		Placeholders:   opts.ScaffoldPlaceholders,
+		Matrix:         opts.ScaffoldMatrix,
+		TemplatePreset: opts.ScaffoldTemplatePreset,
+	})
+
+	hash := sha256.Sum256([]byte(inputs))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// writeScaffoldLock writes the lock to the given directory.
+func writeScaffoldLock(dir string, lock *scaffoldLock) error {
+	content, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	const ownerWriteGlobalReadPerms = 0644
+
+	if err := os.WriteFile(filepath.Join(dir, LockFile), append(content, '\n'), ownerWriteGlobalReadPerms); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func readScaffoldLock(dir string) (*scaffoldLock, error) {
+	content, err := os.ReadFile(filepath.Join(dir, LockFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New(LockFileNotFoundError(dir))
+		}
+
+		return nil, errors.New(err)
+	}
+
+	lock := &scaffoldLock{}
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return lock, nil
+}
+
+// verifyScaffoldLock compares the lock in the working directory with the given lock re-resolved from the same
+// arguments. A different Terragrunt version is only logged, since the sources and the inputs are still the same.
+func verifyScaffoldLock(opts *options.TerragruntOptions, resolved *scaffoldLock) error {
+	locked, err := readScaffoldLock(opts.WorkingDir)
+	if err != nil {
+		return err
+	}
+
+	if locked.TerragruntVersion != resolved.TerragruntVersion {
+		opts.Logger.Warnf("The files were scaffolded with Terragrunt %s, the current version is %s", locked.TerragruntVersion, resolved.TerragruntVersion)
+	}
+
+	var mismatches []string
+
+	for _, field := range []struct {
+		name             string
+		locked, resolved string
+	}{
+		{"module_url", locked.ModuleURL, resolved.ModuleURL},
+		{"ref", locked.Ref, resolved.Ref},
+		{"commit", locked.Commit, resolved.Commit},
+		{"template_url", locked.TemplateURL, resolved.TemplateURL},
+		{"template_ref", locked.TemplateRef, resolved.TemplateRef},
+		{"inputs_hash", locked.InputsHash, resolved.InputsHash},
+	} {
+		if field.locked != field.resolved {
+			opts.Logger.Debugf("The %s of %s is %q, resolved %q", field.name, LockFile, field.locked, field.resolved)
+			mismatches = append(mismatches, field.name)
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return errors.New(LockMismatchError{dir: opts.WorkingDir, fields: mismatches})
+	}
+
+	opts.Logger.Infof("The scaffolded files in %s match %s", opts.WorkingDir, LockFile)
+
+	return nil
+}
@@ -0,0 +1,342 @@
+package scaffold
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gruntwork-io/terragrunt/awshelper"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/hashicorp/go-getter/v2"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+const (
+	s3Getter  = "s3"
+	gcsGetter = "gcs"
+
+	// defaultS3Region is used for the S3 urls without the region, e.g. https://s3.amazonaws.com/bucket/module.
+	defaultS3Region = "us-east-1"
+
+	// gcsPathPrefix is the path prefix of the GCS urls, e.g. https://www.googleapis.com/storage/v1/bucket/module.
+	gcsPathPrefix = "/storage/v1/"
+
+	objectStoreFileMode = 0644
+)
+
+// isObjectStoreSourceURL returns true if the given source url is downloaded from an S3 or GCS bucket,
+// e.g. s3::https://s3-eu-west-1.amazonaws.com/bucket/module. Object store sources are not git repositories,
+// so they are neither rewritten to git ssh nor pinned to a git ref.
+func isObjectStoreSourceURL(sourceURL *url.URL) bool {
+	forcedGetter, _, _ := strings.Cut(sourceURL.Scheme, "::")
+
+	return forcedGetter == s3Getter || forcedGetter == gcsGetter
+}
+
+// objectStoreGetter downloads the objects of a bucket under the path of the url, as a directory, or a single object,
+// as a file. Unlike the v1 client, the go-getter v2 client has no S3 and GCS getters, `newBucket` returns the bucket
+// of the url with the credentials set by the options, and the object key.
+type objectStoreGetter struct {
+	name      string
+	newBucket func(ctx context.Context, sourceURL *url.URL) (objectStoreBucket, string, error)
+}
+
+// objectStoreBucket is a bucket of an object store, the keys are the object paths relative to the bucket.
+type objectStoreBucket interface {
+	// List returns the keys of the objects with the given prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+	// Download writes the object with the given key to the destination file.
+	Download(ctx context.Context, req *getter.Request, key, dst string) error
+}
+
+// Detect implements `getter.Getter` interface, the getter is used only if it's forced, e.g. `s3::https://...`.
+func (g *objectStoreGetter) Detect(req *getter.Request) (bool, error) {
+	return req.Forced == g.name, nil
+}
+
+// Mode implements `getter.Getter` interface.
+func (g *objectStoreGetter) Mode(ctx context.Context, sourceURL *url.URL) (getter.Mode, error) {
+	bucket, key, err := g.newBucket(ctx, sourceURL)
+	if err != nil {
+		return 0, err
+	}
+
+	keys, err := bucket.List(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, objectKey := range keys {
+		if objectKey == key {
+			return getter.ModeFile, nil
+		}
+	}
+
+	return getter.ModeDir, nil
+}
+
+// Get implements `getter.Getter` interface.
+func (g *objectStoreGetter) Get(ctx context.Context, req *getter.Request) error {
+	bucket, key, err := g.newBucket(ctx, req.URL())
+	if err != nil {
+		return err
+	}
+
+	prefix := strings.TrimSuffix(key, "/") + "/"
+
+	keys, err := bucket.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, objectKey := range keys {
+		// skip the directory placeholders, e.g. `modules/vpc/`
+		if strings.HasSuffix(objectKey, "/") {
+			continue
+		}
+
+		relPath := strings.TrimPrefix(objectKey, prefix)
+		if !filepath.IsLocal(filepath.FromSlash(relPath)) {
+			return errors.Errorf("object %s is outside of %s", objectKey, prefix)
+		}
+
+		if err := bucket.Download(ctx, req, objectKey, filepath.Join(req.Dst, filepath.FromSlash(relPath))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetFile implements `getter.Getter` interface.
+func (g *objectStoreGetter) GetFile(ctx context.Context, req *getter.Request) error {
+	bucket, key, err := g.newBucket(ctx, req.URL())
+	if err != nil {
+		return err
+	}
+
+	return bucket.Download(ctx, req, key, req.Dst)
+}
+
+// newS3ObjectStoreGetter returns the getter downloading from S3 with the credentials of the role set with
+// `--terragrunt-scaffold-s3-role-arn`, or the role set with `--terragrunt-iam-role`, falling back to the default
+// credential chain. The credentials are never logged.
+func newS3ObjectStoreGetter(opts *options.TerragruntOptions) getter.Getter {
+	return &objectStoreGetter{
+		name: s3Getter,
+		newBucket: func(_ context.Context, sourceURL *url.URL) (objectStoreBucket, string, error) {
+			config, bucket, key, err := parseS3URL(sourceURL)
+			if err != nil {
+				return nil, "", err
+			}
+
+			config.RoleArn = opts.ScaffoldS3RoleARN
+
+			if config.RoleArn != "" {
+				opts.Logger.Debugf("Assuming role %s to download %s", config.RoleArn, redactSourceURL(sourceURL.String()))
+			}
+
+			sess, err := awshelper.CreateAwsSessionFromConfig(config, opts)
+			if err != nil {
+				return nil, "", err
+			}
+
+			return &s3Bucket{client: s3.New(sess), name: bucket}, key, nil
+		},
+	}
+}
+
+// parseS3URL returns the session config, the bucket and the object key of the S3 url, either path-style,
+// e.g. https://s3-eu-west-1.amazonaws.com/bucket/module, or virtual-hosted-style,
+// e.g. https://bucket.s3.eu-west-1.amazonaws.com/module. The urls of other hosts are treated as path-style urls
+// of S3 compatible services.
+func parseS3URL(sourceURL *url.URL) (*awshelper.AwsSessionConfig, string, string, error) {
+	config := &awshelper.AwsSessionConfig{Region: defaultS3Region}
+	urlPath := strings.TrimPrefix(sourceURL.Path, "/")
+
+	var bucket, key string
+
+	if host := sourceURL.Hostname(); strings.HasSuffix(host, ".amazonaws.com") {
+		hostParts := strings.Split(strings.TrimSuffix(host, ".amazonaws.com"), ".")
+
+		// bucket.s3.region, bucket.s3-region or bucket.s3
+		if len(hostParts) > 1 && strings.HasPrefix(hostParts[1], "s3") {
+			bucket, hostParts = hostParts[0], hostParts[1:]
+		}
+
+		region := strings.TrimPrefix(strings.TrimPrefix(hostParts[0], s3Getter), "-")
+		if len(hostParts) > 1 {
+			region = hostParts[1]
+		}
+
+		if region != "" {
+			config.Region = region
+		}
+	} else {
+		config.CustomS3Endpoint = sourceURL.Scheme + "://" + sourceURL.Host
+		config.S3ForcePathStyle = true
+	}
+
+	if bucket == "" {
+		bucket, key, _ = strings.Cut(urlPath, "/")
+	} else {
+		key = urlPath
+	}
+
+	if bucket == "" || key == "" {
+		return nil, "", "", errors.Errorf("url %s has no bucket or object path", sourceURL.Redacted())
+	}
+
+	return config, bucket, key, nil
+}
+
+type s3Bucket struct {
+	client *s3.S3
+	name   string
+}
+
+// List implements `objectStoreBucket` interface.
+func (bucket *s3Bucket) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket.name),
+		Prefix: aws.String(prefix),
+	}
+
+	err := bucket.client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to list objects of s3 bucket %s: %w", bucket.name, err)
+	}
+
+	return keys, nil
+}
+
+// Download implements `objectStoreBucket` interface.
+func (bucket *s3Bucket) Download(ctx context.Context, req *getter.Request, key, dst string) error {
+	output, err := bucket.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket.name),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return errors.Errorf("failed to download object %s of s3 bucket %s: %w", key, bucket.name, err)
+	}
+	defer output.Body.Close() //nolint:errcheck
+
+	return writeObject(req, output.Body, dst)
+}
+
+// newGCSObjectStoreGetter returns the getter downloading from GCS as the service account set with
+// `--terragrunt-scaffold-gcs-service-account`, falling back to the application default credentials.
+// The credentials are never logged.
+func newGCSObjectStoreGetter(opts *options.TerragruntOptions) getter.Getter {
+	return &objectStoreGetter{
+		name: gcsGetter,
+		newBucket: func(ctx context.Context, sourceURL *url.URL) (objectStoreBucket, string, error) {
+			bucket, key, err := parseGCSURL(sourceURL)
+			if err != nil {
+				return nil, "", err
+			}
+
+			var clientOpts []option.ClientOption
+
+			if serviceAccount := opts.ScaffoldGCSServiceAccount; serviceAccount != "" {
+				opts.Logger.Debugf("Impersonating service account %s to download %s", serviceAccount, redactSourceURL(sourceURL.String()))
+
+				tokenSource, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+					TargetPrincipal: serviceAccount,
+					Scopes:          []string{storage.ScopeReadOnly},
+				})
+				if err != nil {
+					return nil, "", errors.New(err)
+				}
+
+				clientOpts = append(clientOpts, option.WithTokenSource(tokenSource))
+			}
+
+			client, err := storage.NewClient(ctx, clientOpts...)
+			if err != nil {
+				return nil, "", errors.New(err)
+			}
+
+			return &gcsBucket{handle: client.Bucket(bucket), name: bucket}, key, nil
+		},
+	}
+}
+
+// parseGCSURL returns the bucket and the object path of the GCS url, e.g. https://www.googleapis.com/storage/v1/bucket/module.
+func parseGCSURL(sourceURL *url.URL) (string, string, error) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(sourceURL.Path, gcsPathPrefix), "/")
+
+	if !strings.HasPrefix(sourceURL.Path, gcsPathPrefix) || bucket == "" || key == "" {
+		return "", "", errors.Errorf("url %s is not in the form https://www.googleapis.com/storage/v1/<bucket>/<path>", sourceURL.Redacted())
+	}
+
+	return bucket, key, nil
+}
+
+type gcsBucket struct {
+	handle *storage.BucketHandle
+	name   string
+}
+
+// List implements `objectStoreBucket` interface.
+func (bucket *gcsBucket) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+
+	objects := bucket.handle.Objects(ctx, &storage.Query{Prefix: prefix})
+
+	for {
+		object, err := objects.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.Errorf("failed to list objects of gcs bucket %s: %w", bucket.name, err)
+		}
+
+		keys = append(keys, object.Name)
+	}
+
+	return keys, nil
+}
+
+// Download implements `objectStoreBucket` interface.
+func (bucket *gcsBucket) Download(ctx context.Context, req *getter.Request, key, dst string) error {
+	reader, err := bucket.handle.Object(key).NewReader(ctx)
+	if err != nil {
+		return errors.Errorf("failed to download object %s of gcs bucket %s: %w", key, bucket.name, err)
+	}
+	defer reader.Close() //nolint:errcheck
+
+	return writeObject(req, reader, dst)
+}
+
+// writeObject writes the content of the downloaded object to the destination file, creating its directories.
+func writeObject(req *getter.Request, reader io.Reader, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	if err := req.CopyReader(dst, reader, objectStoreFileMode, 0); err != nil {
+		return errors.Errorf("failed to write %s: %w", filepath.Base(dst), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package scaffold
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// MinimalTemplatePreset is the built-in template generating `terragrunt.hcl` with the module inputs, used by default.
+	MinimalTemplatePreset = "minimal"
+	// StandardTemplatePreset is the built-in template which additionally generates the remote state backend,
+	// the provider configuration and the example values of the optional variables by default.
+	StandardTemplatePreset = "standard"
+
+	templatePresetsDir = "presets"
+)
+
+// templatePresetNames are the names of the built-in templates selected with `--terragrunt-scaffold-template-preset`.
+var templatePresetNames = []string{MinimalTemplatePreset, StandardTemplatePreset} //nolint:gochecknoglobals
+
+// the `all:` prefix embeds the dot files, such as `.terraform-version` and `.gitignore`
+//
+//go:embed all:presets
+var templatePresetsFS embed.FS
+
+// templatePresetFiles returns the file names and contents of the built-in template with the given name,
+// the minimal template is returned if the name is empty.
+func templatePresetFiles(name string) (map[string]string, error) {
+	switch name {
+	case "", MinimalTemplatePreset:
+		return map[string]string{
+			DefaultTerragruntTemplateFile: DefaultTerragruntTemplate,
+			DefaultTfvarsExampleFile:      DefaultTfvarsExampleTemplate,
+			DefaultTerraformVersionFile:   DefaultTerraformVersionTemplate,
+			DefaultGitignoreFile:          DefaultGitignoreTemplate,
+			DefaultReadmeFile:             DefaultReadmeTemplate,
+			DefaultBoilerplateConfigFile:  DefaultBoilerplateConfig,
+		}, nil
+	case StandardTemplatePreset:
+		return readTemplatePreset(path.Join(templatePresetsDir, name))
+	}
+
+	return nil, errors.New(UnknownTemplatePresetError(name))
+}
+
+func readTemplatePreset(dir string) (map[string]string, error) {
+	entries, err := fs.ReadDir(templatePresetsFS, dir)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	files := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		content, err := fs.ReadFile(templatePresetsFS, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		files[entry.Name()] = string(content)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,109 @@
+
+# This is a Terragrunt module generated by boilerplate.
+{{- if and (hasKey $ "moduleDeprecation") .moduleDeprecation }}
+#
+# DEPRECATED: the module is deprecated.{{ if .moduleDeprecation.Reason }} {{ .moduleDeprecation.Reason | replaceAll "\n" " " }}{{ end }}
+{{- if .moduleDeprecation.Replacement }}
+# Use {{ .moduleDeprecation.Replacement }} instead.
+{{- end }}
+{{- if .moduleDeprecation.Link }}
+# See {{ .moduleDeprecation.Link }}
+{{- end }}
+{{- end }}
+terraform {
+  source = "{{ .sourceUrl }}"
+}
+{{ if .EnableRootInclude }}
+include "root" {
+  path = find_in_parent_folders()
+}
+{{ end }}
+{{- if .GenerateBackend }}
+remote_state {
+  backend = "s3"
+  generate = {
+    path      = "backend.tf"
+    if_exists = "overwrite_terragrunt"
+  }
+  config = {
+    bucket  = "{{ if .BackendBucket }}{{ .BackendBucket }}{{ else }}{{ .modulePath | replaceAll "/" "-" }}-terraform-state{{ end }}"
+    key     = "{{ .modulePath }}/terraform.tfstate"
+    region  = "{{ .BackendRegion }}"
+    encrypt = true
+  }
+}
+{{ end }}
+{{- if and .GenerateProviders .requiredProviders }}
+# ----------------------------------------------------------------------------------------------------------------------
+# Providers required by the module
+# Uncomment and configure the providers which are not configured by the included root module
+# ----------------------------------------------------------------------------------------------------------------------
+# generate "provider" {
+#   path      = "provider.tf"
+#   if_exists = "overwrite_terragrunt"
+#   contents  = <<EOF
+{{- range .requiredProviders }}
+# provider "{{ .Name }}" {  # {{ if .Source }}{{ .Source }}{{ else }}{{ .Name }}{{ end }}{{ if .Version }} {{ .Version }}{{ end }}
+# }
+{{- end }}
+# EOF
+# }
+{{ end }}
+{{- if and .GenerateDependencies .outputs }}
+# ----------------------------------------------------------------------------------------------------------------------
+# Dependency block for the modules using the outputs of this module
+# ----------------------------------------------------------------------------------------------------------------------
+# dependency "{{ .modulePath | base }}" {
+#   config_path = "../{{ .modulePath | base }}"  # TODO: fill in value
+#
+#   mock_outputs = {
+{{- range .outputs }}
+#     {{ .Name }} = ""{{ if .Description }}  # {{ .Description | replaceAll "\n" " " }}{{ end }}
+{{- end }}
+#   }
+# }
+{{ end }}
+inputs = {
+  # --------------------------------------------------------------------------------------------------------------------
+  # Required input variables
+  # --------------------------------------------------------------------------------------------------------------------
+  {{ range .requiredVariables }}
+  {{- if eq 1 (regexSplit "\n" .Description -1 | len ) }}
+  # Description: {{ .Description }}
+  {{- else }}
+  # Description:
+    {{- range $line := regexSplit "\n" .Description -1 }}
+    # {{ $line | indent 2 }}
+    {{- end }}
+  {{- end }}
+  # Type: {{ .Type }}
+  {{- if .Sensitive }}
+  # SENSITIVE
+  # {{ .Name }} = {{ .DefaultValuePlaceholder }}  # TODO: set via environment, do not commit
+  {{- else if and (hasKey $ "placeholders") (index $.placeholders .Name) }}
+  {{ .Name }} = {{ index $.placeholders .Name }}
+  {{- else }}
+  {{ .Name }} = {{ .DefaultValuePlaceholder }}  # TODO: fill in value
+  {{- end }}
+  {{ end }}
+
+  # --------------------------------------------------------------------------------------------------------------------
+  # Optional input variables
+  # Uncomment the ones you wish to set{{ if .GenerateExampleVars }}, see inputs.auto.tfvars.example for their values{{ end }}
+  # --------------------------------------------------------------------------------------------------------------------
+  {{ range .optionalVariables }}
+  {{- if eq 1 (regexSplit "\n" .Description -1 | len ) }}
+  # Description: {{ .Description }}
+  {{- else }}
+  # Description:
+    {{- range $line := regexSplit "\n" .Description -1 }}
+    # {{ $line | indent 2 }}
+    {{- end }}
+  {{- end }}
+  # Type: {{ .Type }}
+  {{- if .Sensitive }}
+  # SENSITIVE
+  {{- end }}
+  # {{ .Name }} = {{ .DefaultValue | replaceAll "\n" "\n  # " }}
+  {{ end }}
+}
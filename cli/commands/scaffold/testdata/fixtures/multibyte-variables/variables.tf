@@ -0,0 +1,18 @@
+variable "region" {
+  description = "Région de déploiement"
+  type        = string
+}
+
+variable "namespace" {
+  description = "名前空間 \"本番\""
+  type        = string
+}
+
+variable "labels" {
+  description = <<EOT
+Étiquettes appliquées aux ressources
+リソースに付けるラベル
+EOT
+  type        = map(string)
+  default     = {}
+}
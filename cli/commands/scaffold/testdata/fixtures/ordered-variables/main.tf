@@ -0,0 +1,15 @@
+variable "zone" {
+  description = "Zone to deploy to"
+  type        = string
+}
+
+variable "region" {
+  description = "Region to deploy to"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "cluster_name" {
+  description = "Name of the cluster"
+  type        = string
+}
@@ -0,0 +1,16 @@
+variable "name" {
+  description = "Name of the service"
+  type        = string
+}
+
+variable "instance_count" {
+  description = "Number of instances"
+  type        = number
+  default     = 1
+}
+
+variable "enabled" {
+  description = "Whether the service is enabled"
+  type        = bool
+  default     = true
+}
@@ -0,0 +1,17 @@
+variable "username" {
+  description = "Database username"
+  type        = string
+}
+
+variable "password" {
+  description = "Database password"
+  type        = string
+  sensitive   = true
+}
+
+variable "api_token" {
+  description = "Token of the monitoring API"
+  type        = string
+  default     = ""
+  sensitive   = true
+}
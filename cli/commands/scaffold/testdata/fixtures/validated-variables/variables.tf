@@ -0,0 +1,44 @@
+variable "environment" {
+  description = "Name of the environment"
+  type        = string
+  default     = "dev"
+
+  validation {
+    condition     = contains(["dev", "stage", "prod"], var.environment)
+    error_message = "The environment must be one of dev, stage or prod."
+  }
+}
+
+variable "instance_count" {
+  description = "Number of instances"
+  type        = number
+  nullable    = false
+
+  validation {
+    condition     = var.instance_count > 0
+    error_message = "The instance count must be positive."
+  }
+
+  validation {
+    condition     = var.instance_count <= 10
+    error_message = "The instance count must not exceed 10."
+  }
+}
+
+variable "session_token" {
+  description = "Token of the current session"
+  type        = string
+  default     = ""
+  sensitive   = true
+  ephemeral   = true
+}
+
+variable "tags" {
+  description = "Tags of the resources"
+  type = object({
+    owner = string
+    team  = optional(string, "platform")
+  })
+  default  = null
+  nullable = true
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/hashicorp/go-getter"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/zclconf/go-cty/cty"
 
@@ -740,6 +741,30 @@ func ReadTerragruntConfig(ctx context.Context, terragruntOptions *options.Terrag
 	return ParseConfigFile(parcingCtx, terragruntOptions.TerragruntConfigPath, nil) //nolint:contextcheck
 }
 
+// ValidateConfigSchema checks that the Terragrunt config file at the given path is valid HCL and its top-level blocks
+// and attributes are supported, without evaluating any expressions, so it doesn't depend on the included configs,
+// the dependencies or the environment. Returns the diagnostics pointing at the offending blocks.
+func ValidateConfigSchema(terragruntOptions *options.TerragruntOptions, configPath string) error {
+	file, err := hclparse.NewParser(hclparse.WithLogger(terragruntOptions.Logger)).ParseFromFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	// the include blocks without a label are supported the same way as by `ParseConfig`
+	if err := updateBareIncludeBlock(file); err != nil {
+		return err
+	}
+
+	schema, _ := gohcl.ImpliedBodySchema(&terragruntConfigFile{})
+
+	_, diags := file.Body.Content(schema)
+	if err := file.HandleDiagnostics(diags); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
 // ParseConfigFile parses the Terragrunt config file at the given path. If the include parameter is not nil, then treat this as a config
 // included in some other config file when resolving relative paths.
 func ParseConfigFile(ctx *ParsingContext, configPath string, includeFromChild *IncludeConfig) (*TerragruntConfig, error) {
@@ -1383,3 +1383,67 @@ func BenchmarkReadTerragruntConfig(b *testing.B) {
 		})
 	}
 }
+
+func TestValidateConfigSchema(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		cfg         string
+		expectedErr string
+	}{
+		{
+			cfg: `
+include {
+  path = find_in_parent_folders("root.hcl")
+}
+
+remote_state {
+  backend = "s3"
+  config  = { bucket = local.bucket }
+}
+
+generate "provider" {
+  path     = "provider.tf"
+  contents = ""
+}
+
+inputs = {
+  name = dependency.vpc.outputs.name
+}
+`,
+		},
+		{
+			cfg:         `inptus = {}`,
+			expectedErr: "Unsupported argument",
+		},
+		{
+			cfg:         `terraform "module" {}`,
+			expectedErr: "Extraneous label",
+		},
+		{
+			cfg:         `inputs = {`,
+			expectedErr: "Missing expression",
+		},
+	}
+
+	for i, testCase := range testCases {
+		t.Run(fmt.Sprintf("testCase-%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			configPath := filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath)
+			require.NoError(t, os.WriteFile(configPath, []byte(testCase.cfg), 0644))
+
+			opts, err := options.NewTerragruntOptionsForTest(configPath)
+			require.NoError(t, err)
+
+			err = config.ValidateConfigSchema(opts, configPath)
+			if testCase.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expectedErr)
+		})
+	}
+}
@@ -243,3 +243,16 @@ type DependencyCycleError []string
 func (err DependencyCycleError) Error() string {
 	return "Found a dependency cycle between modules: " + strings.Join([]string(err), " -> ")
 }
+
+type VariablesFileParseError struct {
+	Path string
+	Err  error
+}
+
+func (err VariablesFileParseError) Error() string {
+	return fmt.Sprintf("Failed to parse variables from %s: %v", err.Path, err.Err)
+}
+
+func (err VariablesFileParseError) Unwrap() error {
+	return err.Err
+}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
@@ -12,8 +15,67 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"golang.org/x/exp/maps"
 )
 
+var (
+	// variableBlockSchema - schema of the variable blocks in tf files.
+	variableBlockSchema = &hcl.BodySchema{ //nolint:gochecknoglobals
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "variable", LabelNames: []string{"name"}},
+		},
+	}
+
+	// terraformBlockSchema - schema of the terraform blocks in tf files.
+	terraformBlockSchema = &hcl.BodySchema{ //nolint:gochecknoglobals
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "terraform"},
+		},
+	}
+
+	// terraformAttributesSchema - schema of the terraform block attributes used by scaffold.
+	terraformAttributesSchema = &hcl.BodySchema{ //nolint:gochecknoglobals
+		Attributes: []hcl.AttributeSchema{
+			{Name: "required_version"},
+		},
+	}
+
+	// requiredProvidersBlockSchema - schema of the required_providers blocks inside the terraform block.
+	requiredProvidersBlockSchema = &hcl.BodySchema{ //nolint:gochecknoglobals
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "required_providers"},
+		},
+	}
+
+	// outputBlockSchema - schema of the output blocks in tf files.
+	outputBlockSchema = &hcl.BodySchema{ //nolint:gochecknoglobals
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "output", LabelNames: []string{"name"}},
+		},
+	}
+
+	// outputAttributesSchema - schema of the output block attributes used by scaffold, the `value` is not evaluated.
+	outputAttributesSchema = &hcl.BodySchema{ //nolint:gochecknoglobals
+		Attributes: []hcl.AttributeSchema{
+			{Name: "description"},
+			{Name: "sensitive"},
+		},
+	}
+
+	// variableAttributesSchema - schema of the variable block attributes used by scaffold.
+	variableAttributesSchema = &hcl.BodySchema{ //nolint:gochecknoglobals
+		Attributes: []hcl.AttributeSchema{
+			{Name: "description"},
+			{Name: "type"},
+			{Name: "default"},
+			{Name: "sensitive"},
+		},
+	}
+)
+
+// NullDefaultValue is the `DefaultValue` of variables that declare `default = null`.
+const NullDefaultValue = "null"
+
 // ParsedVariable structure with input name, default value and description.
 type ParsedVariable struct {
 	Name                    string
@@ -21,109 +83,455 @@ type ParsedVariable struct {
 	Type                    string
 	DefaultValue            string
 	DefaultValuePlaceholder string
+	Sensitive               bool
+	// DeclRange is the source range of the variable block declaration.
+	DeclRange hcl.Range
+}
+
+// ParsedProvider structure with provider name, source address and version constraints.
+type ParsedProvider struct {
+	Name    string
+	Source  string
+	Version string
+}
+
+// ParsedOutput structure with output name and description.
+type ParsedOutput struct {
+	Name        string
+	Description string
+	Sensitive   bool
+	// DeclRange is the source range of the output block declaration.
+	DeclRange hcl.Range
 }
 
 // ParseVariables - parse variables from tf files.
 func ParseVariables(opts *options.TerragruntOptions, directoryPath string) ([]*ParsedVariable, error) {
-	// list all tf files
+	files, err := parseTfFiles(opts, directoryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// iterate over files and parse variables.
+	var parsedInputs []*ParsedVariable
+
+	for _, file := range files {
+		inputs, err := parseFileVariables(opts, file)
+		if err != nil {
+			return nil, err
+		}
+
+		parsedInputs = append(parsedInputs, inputs...)
+	}
+
+	return parsedInputs, nil
+}
+
+// ParseVariablesLenient - parse variables from tf files the same as `ParseVariables`, but the files which fail to parse
+// are skipped instead of failing the whole parsing. Returns the variables of the parsed files and, if any file is skipped,
+// the `MultiError` with the `VariablesFileParseError` of each skipped file.
+func ParseVariablesLenient(opts *options.TerragruntOptions, directoryPath string) ([]*ParsedVariable, error) {
 	tfFiles, err := util.ListTfFiles(directoryPath)
 	if err != nil {
 		return nil, errors.New(err)
 	}
 
-	parser := hclparse.NewParser(DefaultParserOptions(opts)...)
+	sort.Strings(tfFiles)
 
-	// iterate over files and parse variables.
-	var parsedInputs []*ParsedVariable
+	var (
+		parser       = hclparse.NewParser(DefaultParserOptions(opts)...)
+		parsedInputs []*ParsedVariable
+		errs         *errors.MultiError
+	)
 
 	for _, tfFile := range tfFiles {
-		if _, err := parser.ParseFromFile(tfFile); err != nil {
+		file, err := parser.ParseFromFile(tfFile)
+		if err == nil {
+			var inputs []*ParsedVariable
+
+			if inputs, err = parseFileVariables(opts, file.File); err == nil {
+				parsedInputs = append(parsedInputs, inputs...)
+				continue
+			}
+		}
+
+		errs = errs.Append(errors.New(VariablesFileParseError{Path: tfFile, Err: err}))
+	}
+
+	return parsedInputs, errs.ErrorOrNil()
+}
+
+// parseFileVariables - parse the variable blocks of the given tf file.
+func parseFileVariables(opts *options.TerragruntOptions, file *hcl.File) ([]*ParsedVariable, error) {
+	ctx := &hcl.EvalContext{}
+
+	// use the schema instead of the native syntax body, so that JSON files are parsed the same way.
+	content, _, diags := file.Body.PartialContent(variableBlockSchema)
+	if diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	var parsedInputs []*ParsedVariable
+
+	for _, block := range content.Blocks {
+		if len(block.Labels[0]) == 0 {
+			continue
+		}
+
+		input, err := parseVariableBlock(opts, ctx, block, file.Bytes)
+		if err != nil {
 			return nil, err
 		}
+
+		parsedInputs = append(parsedInputs, input)
 	}
 
-	for _, file := range parser.Files() {
-		ctx := &hcl.EvalContext{}
+	return parsedInputs, nil
+}
+
+// ParseRequiredProviders - parse providers from the `required_providers` blocks of tf files, sorted by name.
+// If a provider is declared in several files, its version constraints are combined.
+func ParseRequiredProviders(opts *options.TerragruntOptions, directoryPath string) ([]*ParsedProvider, error) {
+	files, err := parseTfFiles(opts, directoryPath)
+	if err != nil {
+		return nil, err
+	}
 
-		if body, ok := file.Body.(*hclsyntax.Body); ok {
-			for _, block := range body.Blocks {
-				if block.Type == "variable" {
-					if len(block.Labels[0]) > 0 {
-						// extract variable attributes
-						name := block.Labels[0]
-
-						var descriptionAttrText string
-
-						descriptionAttr, err := readBlockAttribute(ctx, block, "description")
-						if err != nil {
-							opts.Logger.Warnf("Failed to read descriptionAttr for %s %v", name, err)
-
-							descriptionAttr = nil
-						}
-
-						if descriptionAttr != nil {
-							descriptionAttrText = descriptionAttr.AsString()
-						} else {
-							descriptionAttrText = fmt.Sprintf("(variable %s did not define a description)", name)
-						}
-
-						var typeAttrText string
-
-						typeAttr, err := readBlockAttribute(ctx, block, "type")
-						if err != nil {
-							opts.Logger.Warnf("Failed to read type attribute for %s %v", name, err)
-						}
-
-						if typeAttr != nil {
-							typeAttrText = typeAttr.AsString()
-						} else {
-							typeAttrText = fmt.Sprintf("(variable %s does not define a type)", name)
-						}
-
-						defaultValue, err := readBlockAttribute(ctx, block, "default")
-						if err != nil {
-							opts.Logger.Warnf("Failed to read default value for %s %v", name, err)
-
-							defaultValue = nil
-						}
-
-						defaultValueText := ""
-
-						if defaultValue != nil {
-							jsonBytes, err := ctyjson.Marshal(*defaultValue, cty.DynamicPseudoType)
-							if err != nil {
-								return nil, errors.New(err)
-							}
-
-							var ctyJSONOutput ctyJSONValue
-							if err := json.Unmarshal(jsonBytes, &ctyJSONOutput); err != nil {
-								return nil, errors.New(err)
-							}
-
-							jsonBytes, err = json.Marshal(ctyJSONOutput.Value)
-							if err != nil {
-								return nil, errors.New(err)
-							}
-
-							defaultValueText = string(jsonBytes)
-						}
-
-						input := &ParsedVariable{
-							Name:                    name,
-							Type:                    typeAttrText,
-							Description:             descriptionAttrText,
-							DefaultValue:            defaultValueText,
-							DefaultValuePlaceholder: generateDefaultValue(typeAttrText),
-						}
-
-						parsedInputs = append(parsedInputs, input)
+	providerByName := map[string]*ParsedProvider{}
+
+	for _, file := range files {
+		content, _, diags := file.Body.PartialContent(terraformBlockSchema)
+		if diags.HasErrors() {
+			return nil, errors.New(diags)
+		}
+
+		for _, terraformBlock := range content.Blocks {
+			terraformContent, _, diags := terraformBlock.Body.PartialContent(requiredProvidersBlockSchema)
+			if diags.HasErrors() {
+				return nil, errors.New(diags)
+			}
+
+			for _, block := range terraformContent.Blocks {
+				attrs, diags := block.Body.JustAttributes()
+				if diags.HasErrors() {
+					return nil, errors.New(diags)
+				}
+
+				for name, attr := range attrs {
+					provider, err := parseRequiredProvider(name, attr)
+					if err != nil {
+						opts.Logger.Warnf("Failed to read required provider %s %v", name, err)
+
+						continue
+					}
+
+					existing, ok := providerByName[name]
+					if !ok {
+						providerByName[name] = provider
+
+						continue
+					}
+
+					if existing.Source == "" {
+						existing.Source = provider.Source
+					}
+
+					if provider.Version != "" && existing.Version != "" {
+						existing.Version += ", " + provider.Version
+					} else if provider.Version != "" {
+						existing.Version = provider.Version
 					}
 				}
 			}
 		}
 	}
 
-	return parsedInputs, nil
+	providers := make([]*ParsedProvider, 0, len(providerByName))
+	for _, provider := range providerByName {
+		providers = append(providers, provider)
+	}
+
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Name < providers[j].Name
+	})
+
+	return providers, nil
+}
+
+// ParseRequiredVersion - parse the `required_version` constraints of the terraform blocks of tf files.
+// If the constraint is declared in several files, the constraints are combined, e.g. `>= 1.5, < 2.0`.
+func ParseRequiredVersion(opts *options.TerragruntOptions, directoryPath string) (string, error) {
+	files, err := parseTfFiles(opts, directoryPath)
+	if err != nil {
+		return "", err
+	}
+
+	var constraints []string
+
+	for _, file := range files {
+		content, _, diags := file.Body.PartialContent(terraformBlockSchema)
+		if diags.HasErrors() {
+			return "", errors.New(diags)
+		}
+
+		for _, terraformBlock := range content.Blocks {
+			attrs, _, diags := terraformBlock.Body.PartialContent(terraformAttributesSchema)
+			if diags.HasErrors() {
+				return "", errors.New(diags)
+			}
+
+			versionAttr, err := readBlockAttribute(&hcl.EvalContext{}, attrs.Attributes, "required_version")
+			if err != nil {
+				opts.Logger.Warnf("Failed to read required_version attribute %v", err)
+
+				continue
+			}
+
+			if versionAttr != nil && versionAttr.Type() == cty.String && versionAttr.IsKnown() && !versionAttr.IsNull() {
+				if constraint := strings.TrimSpace(versionAttr.AsString()); constraint != "" {
+					constraints = append(constraints, constraint)
+				}
+			}
+		}
+	}
+
+	return strings.Join(constraints, ", "), nil
+}
+
+// ParseOutputs - parse outputs from tf files, in the order of declaration.
+func ParseOutputs(opts *options.TerragruntOptions, directoryPath string) ([]*ParsedOutput, error) {
+	files, err := parseTfFiles(opts, directoryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsedOutputs []*ParsedOutput
+
+	for _, file := range files {
+		ctx := &hcl.EvalContext{}
+
+		content, _, diags := file.Body.PartialContent(outputBlockSchema)
+		if diags.HasErrors() {
+			return nil, errors.New(diags)
+		}
+
+		for _, block := range content.Blocks {
+			name := block.Labels[0]
+
+			attrs, _, diags := block.Body.PartialContent(outputAttributesSchema)
+			if diags.HasErrors() {
+				return nil, errors.New(diags)
+			}
+
+			output := &ParsedOutput{
+				Name:      name,
+				DeclRange: block.DefRange,
+			}
+
+			descriptionAttr, err := readBlockAttribute(ctx, attrs.Attributes, "description")
+			if err != nil {
+				opts.Logger.Warnf("Failed to read description attribute for output %s %v", name, err)
+			} else if descriptionAttr != nil && descriptionAttr.Type() == cty.String && descriptionAttr.IsKnown() && !descriptionAttr.IsNull() {
+				output.Description = descriptionAttr.AsString()
+			}
+
+			sensitiveAttr, err := readBlockAttribute(ctx, attrs.Attributes, "sensitive")
+			if err != nil {
+				opts.Logger.Warnf("Failed to read sensitive attribute for output %s %v", name, err)
+			} else {
+				output.Sensitive = sensitiveAttr != nil && sensitiveAttr.Type() == cty.Bool && sensitiveAttr.IsKnown() && sensitiveAttr.True()
+			}
+
+			parsedOutputs = append(parsedOutputs, output)
+		}
+	}
+
+	return parsedOutputs, nil
+}
+
+// parseRequiredProvider - parse a `required_providers` entry, either an object with `source` and `version`,
+// or a version constraint string of the legacy syntax.
+func parseRequiredProvider(name string, attr *hcl.Attribute) (*ParsedProvider, error) {
+	provider := &ParsedProvider{Name: name}
+
+	pairs, diags := hcl.ExprMap(attr.Expr)
+	if diags.HasErrors() {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		if value.Type() != cty.String || value.IsNull() || !value.IsKnown() {
+			return nil, errors.Errorf("unsupported value of provider requirement %s", name)
+		}
+
+		provider.Version = value.AsString()
+
+		return provider, nil
+	}
+
+	// read only the `source` and `version` keys, other keys such as `configuration_aliases` contain references.
+	for _, pair := range pairs {
+		key, diags := pair.Key.Value(nil)
+		if diags.HasErrors() || key.Type() != cty.String || key.IsNull() {
+			continue
+		}
+
+		var field *string
+
+		switch key.AsString() {
+		case "source":
+			field = &provider.Source
+		case "version":
+			field = &provider.Version
+		default:
+			continue
+		}
+
+		value, diags := pair.Value.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		if value.Type() == cty.String && !value.IsNull() && value.IsKnown() {
+			*field = value.AsString()
+		}
+	}
+
+	return provider, nil
+}
+
+// parseTfFiles - parse all tf files in the given directory, sorted by file name.
+func parseTfFiles(opts *options.TerragruntOptions, directoryPath string) ([]*hcl.File, error) {
+	// list all tf files
+	tfFiles, err := util.ListTfFiles(directoryPath)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	parser := hclparse.NewParser(DefaultParserOptions(opts)...)
+
+	for _, tfFile := range tfFiles {
+		if _, err := parser.ParseFromFile(tfFile); err != nil {
+			return nil, err
+		}
+	}
+
+	parsedFiles := parser.Files()
+	fileNames := maps.Keys(parsedFiles)
+	sort.Strings(fileNames)
+
+	files := make([]*hcl.File, 0, len(fileNames))
+	for _, fileName := range fileNames {
+		files = append(files, parsedFiles[fileName])
+	}
+
+	return files, nil
+}
+
+// parseVariableBlock - parse the attributes of the variable block, the unknown attributes and nested blocks are ignored.
+func parseVariableBlock(opts *options.TerragruntOptions, ctx *hcl.EvalContext, block *hcl.Block, src []byte) (*ParsedVariable, error) {
+	name := block.Labels[0]
+
+	content, _, diags := block.Body.PartialContent(variableAttributesSchema)
+	if diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	var descriptionAttrText string
+
+	descriptionAttr, err := readBlockAttribute(ctx, content.Attributes, "description")
+	if err != nil {
+		opts.Logger.Warnf("Failed to read descriptionAttr for %s %v", name, err)
+
+		descriptionAttr = nil
+	}
+
+	if rawDescription, ok := literalStringSource(content.Attributes["description"], src); ok {
+		descriptionAttrText = rawDescription
+	} else if descriptionAttr != nil {
+		descriptionAttrText = descriptionAttr.AsString()
+	} else {
+		descriptionAttrText = fmt.Sprintf("(variable %s did not define a description)", name)
+	}
+
+	var typeAttrText string
+
+	typeAttr, err := readTypeAttribute(ctx, content.Attributes)
+	if err != nil {
+		opts.Logger.Warnf("Failed to read type attribute for %s %v", name, err)
+	}
+
+	if typeAttr != nil {
+		typeAttrText = typeAttr.AsString()
+	} else {
+		typeAttrText = fmt.Sprintf("(variable %s does not define a type)", name)
+	}
+
+	// the default referencing other values, e.g. `var.environment`, can't be evaluated, so it's kept as written.
+	defaultSource := referencingExpressionSource(content.Attributes["default"], src)
+
+	var defaultValue *cty.Value
+
+	if defaultSource == "" {
+		defaultValue, err = readBlockAttribute(ctx, content.Attributes, "default")
+		if err != nil {
+			opts.Logger.Warnf("Failed to read default value for %s %v", name, err)
+
+			defaultValue = nil
+		}
+	}
+
+	sensitiveAttr, err := readBlockAttribute(ctx, content.Attributes, "sensitive")
+	if err != nil {
+		opts.Logger.Warnf("Failed to read sensitive attribute for %s %v", name, err)
+
+		sensitiveAttr = nil
+	}
+
+	sensitive := sensitiveAttr != nil && sensitiveAttr.Type() == cty.Bool && sensitiveAttr.IsKnown() && sensitiveAttr.True()
+
+	defaultValueText := ""
+
+	switch {
+	case defaultSource != "":
+		defaultValueText = defaultSource
+	case defaultValue == nil:
+	case defaultValue.IsNull():
+		// explicit `default = null` means the value is not required, keep it distinct from a missing default.
+		defaultValueText = NullDefaultValue
+	default:
+		jsonBytes, err := ctyjson.Marshal(*defaultValue, cty.DynamicPseudoType)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		var ctyJSONOutput ctyJSONValue
+		if err := json.Unmarshal(jsonBytes, &ctyJSONOutput); err != nil {
+			return nil, errors.New(err)
+		}
+
+		jsonBytes, err = json.Marshal(ctyJSONOutput.Value)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		defaultValueText = string(jsonBytes)
+	}
+
+	declRange := block.DefRange
+	if body, ok := block.Body.(*hclsyntax.Body); ok {
+		declRange = hcl.RangeBetween(block.DefRange, body.SrcRange)
+	}
+
+	return &ParsedVariable{
+		Name:                    name,
+		Type:                    typeAttrText,
+		Description:             descriptionAttrText,
+		DefaultValue:            defaultValueText,
+		DefaultValuePlaceholder: generateDefaultValue(typeAttrText),
+		Sensitive:               sensitive,
+		DeclRange:               declRange,
+	}, nil
 }
 
 // generateDefaultValue - generate hcl default value
@@ -150,32 +558,159 @@ type ctyJSONValue struct {
 	Type  interface{} `json:"Type"`
 }
 
+// readTypeAttribute - read the type attribute of the variable block.
+// In JSON files the type constraint is a string containing the native syntax expression, e.g. "list(string)",
+// it is parsed to get the same result as for HCL files.
+func readTypeAttribute(ctx *hcl.EvalContext, attrs hcl.Attributes) (*cty.Value, error) {
+	attr, ok := attrs["type"]
+	if !ok || attr.Expr == nil {
+		return nil, nil
+	}
+
+	if _, ok := attr.Expr.(hclsyntax.Expression); ok {
+		return readExpression(ctx, attr.Expr)
+	}
+
+	value, diags := attr.Expr.Value(ctx)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	if value.Type() != cty.String || value.IsNull() || !value.IsKnown() {
+		return &value, nil
+	}
+
+	rng := attr.Expr.Range()
+
+	expr, diags := hclsyntax.ParseExpression([]byte(value.AsString()), rng.Filename, rng.Start)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return readExpression(ctx, expr)
+}
+
 // readBlockAttribute - hcl block attribute.
-func readBlockAttribute(ctx *hcl.EvalContext, block *hclsyntax.Block, name string) (*cty.Value, error) {
-	if attr, ok := block.Body.Attributes[name]; ok {
-		if attr.Expr != nil {
-			if call, ok := attr.Expr.(*hclsyntax.FunctionCallExpr); ok {
-				result := cty.StringVal(call.Name)
-				return &result, nil
+func readBlockAttribute(ctx *hcl.EvalContext, attrs hcl.Attributes, name string) (*cty.Value, error) {
+	if attr, ok := attrs[name]; ok && attr.Expr != nil {
+		return readExpression(ctx, attr.Expr)
+	}
+
+	return nil, nil
+}
+
+// literalStringSource - return the string of the attribute as written in the source, if the attribute is a quoted string
+// or a heredoc without interpolations and directives. Unlike the evaluated value, which is normalized by cty to the
+// Unicode NFC form, the string is preserved byte for byte.
+func literalStringSource(attr *hcl.Attribute, src []byte) (string, bool) {
+	if attr == nil {
+		return "", false
+	}
+
+	if _, ok := attr.Expr.(*hclsyntax.TemplateExpr); !ok {
+		return "", false
+	}
+
+	rng := attr.Expr.Range()
+	if rng.Start.Byte < 0 || rng.End.Byte > len(src) || rng.Start.Byte > rng.End.Byte {
+		return "", false
+	}
+
+	// the rest of the file is lexed, since the closing marker of heredocs is recognized only if it ends with a new line
+	tokens, diags := hclsyntax.LexExpression(src[rng.Start.Byte:], rng.Filename, rng.Start)
+	if diags.HasErrors() {
+		return "", false
+	}
+
+	var str strings.Builder
+
+	for _, token := range tokens {
+		if token.Range.Start.Byte >= rng.End.Byte {
+			break
+		}
+
+		switch token.Type {
+		case hclsyntax.TokenOQuote, hclsyntax.TokenCQuote, hclsyntax.TokenCHeredoc:
+		case hclsyntax.TokenOHeredoc:
+			// the indentation of `<<-` heredocs is trimmed by the parser, so only their evaluated value is correct
+			if bytes.HasPrefix(token.Bytes, []byte("<<-")) {
+				return "", false
 			}
-			// check if first var is traversal
-			if len(attr.Expr.Variables()) > 0 {
-				v := attr.Expr.Variables()[0]
-				// check if variable is traversal
-				if varTr, ok := v[0].(hcl.TraverseRoot); ok {
-					result := cty.StringVal(varTr.Name)
-					return &result, nil
-				}
+		case hclsyntax.TokenQuotedLit, hclsyntax.TokenStringLit:
+			lit, diags := hclsyntax.ParseStringLiteralToken(token)
+			if diags.HasErrors() {
+				return "", false
 			}
 
-			value, err := attr.Expr.Value(ctx)
-			if err != nil {
-				return nil, err
-			}
+			str.WriteString(lit)
+		default:
+			return "", false
+		}
+	}
+
+	return str.String(), true
+}
+
+// referencingExpressionSource - return the source of the attribute expression as written, if the expression references
+// variables, locals or other values, or calls functions, otherwise the empty string is returned. The expressions
+// of JSON files are not native syntax, so their source is not returned.
+func referencingExpressionSource(attr *hcl.Attribute, src []byte) string {
+	if attr == nil {
+		return ""
+	}
+
+	expr, ok := attr.Expr.(hclsyntax.Expression)
+	if !ok {
+		return ""
+	}
+
+	if len(expr.Variables()) == 0 && !hasFunctionCall(expr) {
+		return ""
+	}
+
+	rng := expr.Range()
+	if rng.Start.Byte < 0 || rng.End.Byte > len(src) || rng.Start.Byte >= rng.End.Byte {
+		return ""
+	}
+
+	return string(rng.SliceBytes(src))
+}
+
+// hasFunctionCall - return true if the expression or any of its nested expressions is a function call.
+func hasFunctionCall(expr hclsyntax.Expression) bool {
+	var found bool
+
+	hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics { //nolint:errcheck
+		if _, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+			found = true
+		}
 
-			return &value, nil
+		return nil
+	})
+
+	return found
+}
+
+// readExpression - evaluate the attribute expression, function calls and traversals are returned by their names.
+func readExpression(ctx *hcl.EvalContext, expr hcl.Expression) (*cty.Value, error) {
+	if call, ok := expr.(*hclsyntax.FunctionCallExpr); ok {
+		result := cty.StringVal(call.Name)
+		return &result, nil
+	}
+	// check if first var is traversal
+	if len(expr.Variables()) > 0 {
+		v := expr.Variables()[0]
+		// check if variable is traversal
+		if varTr, ok := v[0].(hcl.TraverseRoot); ok {
+			result := cty.StringVal(varTr.Name)
+			return &result, nil
 		}
 	}
 
-	return nil, nil
+	value, err := expr.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
 }
@@ -1,6 +1,8 @@
 package config_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/config"
@@ -81,3 +83,145 @@ func TestScanDefaultVariables(t *testing.T) {
 	assert.Equal(t, "\"default-vpc\"", varByName["vpc"].DefaultValue)
 	assert.Equal(t, "VPC to be used", varByName["vpc"].Description)
 }
+
+func TestScanExpressionDefaultVariables(t *testing.T) {
+	t.Parallel()
+	opts := terragruntOptionsForTest(t, "")
+
+	inputs, err := config.ParseVariables(opts, "../test/fixtures/inputs-expression-defaults")
+	require.NoError(t, err)
+
+	defaults := map[string]string{}
+	for _, input := range inputs {
+		defaults[input.Name] = input.DefaultValue
+	}
+
+	assert.Equal(t, map[string]string{
+		"environment": "",
+		"name_prefix": "var.environment",
+		"bucket_name": `"${var.environment}-${local.suffix}"`,
+		"tags":        "merge(\n    { Environment = var.environment },\n    { Team = \"platform\" },\n  )",
+		"zones":       `[upper("a"), "b"]`,
+		"port":        "8080",
+	}, defaults)
+}
+
+func TestParseVariablesRawDescriptions(t *testing.T) {
+	t.Parallel()
+
+	opts := terragruntOptionsForTest(t, "")
+
+	dir := t.TempDir()
+	// the accents are written as combining characters, e.g. `e` followed by U+0301
+	src := `
+variable "decomposed" {
+  description = "Région \"quoted\" $${literal}"
+}
+
+variable "heredoc" {
+  description = <<EOT
+Région
+EOT
+}
+
+variable "indented" {
+  description = <<-EOT
+    Région
+  EOT
+}
+
+variable "interpolated" {
+  description = "Région ${"x"}"
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(src), 0644))
+
+	inputs, err := config.ParseVariables(opts, dir)
+	require.NoError(t, err)
+
+	descriptions := map[string]string{}
+	for _, input := range inputs {
+		descriptions[input.Name] = input.Description
+	}
+
+	assert.Equal(t, map[string]string{
+		"decomposed": "Re\u0301gion \"quoted\" ${literal}",
+		"heredoc":    "Re\u0301gion\n",
+		// the expressions which can't be read from the source are evaluated, so their unicode is normalized
+		"indented":     "R\u00e9gion\n",
+		"interpolated": "R\u00e9gion x",
+	}, descriptions)
+}
+
+func TestParseVariablesLenient(t *testing.T) {
+	t.Parallel()
+
+	opts := terragruntOptionsForTest(t, "")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(`variable "region" {}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.tf"), []byte(`variable "broken" {`), 0644))
+
+	_, err := config.ParseVariables(opts, dir)
+	require.Error(t, err)
+
+	inputs, err := config.ParseVariablesLenient(opts, dir)
+	require.Len(t, inputs, 1)
+	assert.Equal(t, "region", inputs[0].Name)
+
+	var parseErr config.VariablesFileParseError
+
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, filepath.Join(dir, "broken.tf"), parseErr.Path)
+}
+
+func TestParseRequiredProviders(t *testing.T) {
+	t.Parallel()
+
+	opts := terragruntOptionsForTest(t, "")
+
+	providers, err := config.ParseRequiredProviders(opts, "../test/fixtures/required-providers")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*config.ParsedProvider{
+		{Name: "aws", Source: "hashicorp/aws", Version: "< 6.0, >= 5.0"},
+		{Name: "random", Version: "~> 3.5"},
+		{Name: "tls", Source: "hashicorp/tls"},
+	}, providers)
+}
+
+func TestParseRequiredVersion(t *testing.T) {
+	t.Parallel()
+
+	opts := terragruntOptionsForTest(t, "")
+
+	requiredVersion, err := config.ParseRequiredVersion(opts, "../test/fixtures/required-providers")
+	require.NoError(t, err)
+	assert.Equal(t, "< 2.0, >= 1.5", requiredVersion)
+
+	requiredVersion, err = config.ParseRequiredVersion(opts, "../test/fixtures/outputs-scan")
+	require.NoError(t, err)
+	assert.Empty(t, requiredVersion)
+}
+
+func TestParseOutputs(t *testing.T) {
+	t.Parallel()
+
+	opts := terragruntOptionsForTest(t, "")
+
+	outputs, err := config.ParseOutputs(opts, "../test/fixtures/outputs-scan")
+	require.NoError(t, err)
+
+	var names []string
+	for _, output := range outputs {
+		names = append(names, output.Name)
+	}
+
+	assert.Equal(t, []string{"cluster_name", "vpc_id", "subnet_ids", "db_password"}, names)
+
+	assert.Equal(t, "Name of the cluster", outputs[0].Description)
+	assert.Equal(t, "ID of the VPC", outputs[1].Description)
+	assert.Empty(t, outputs[2].Description)
+	assert.False(t, outputs[2].Sensitive)
+	assert.True(t, outputs[3].Sensitive)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/os/signal"
@@ -27,6 +28,12 @@ type Cmd struct {
 
 	forwardSignalDelay time.Duration
 	interruptSignal    os.Signal
+
+	processGroup bool
+
+	// sentSignal is the last signal sent to the executed command.
+	sentSignal   os.Signal
+	sentSignalMu sync.Mutex
 }
 
 // Command returns the `Cmd` struct to execute the named program with
@@ -55,6 +62,11 @@ func (cmd *Cmd) Configure(opts ...Option) {
 
 // Start starts the specified command but does not wait for it to complete.
 func (cmd *Cmd) Start() error {
+	// The pseudo-terminal routine starts the command in a new session, which is already a separate process group.
+	if cmd.processGroup && !cmd.usePTY {
+		setProcessGroup(cmd.Cmd)
+	}
+
 	// If we need to allocate a ptty for the command, route through the ptty routine.
 	// Otherwise, directly call the command.
 	if cmd.usePTY {
@@ -75,6 +87,9 @@ func (cmd *Cmd) Start() error {
 //     Thus we will send the signal to the executed command with a delay or immediately if Terragrunt receives this same signal again.
 //  2. If the context does not contain any causes, this means that there was some failure and we need to terminate all executed commands,
 //     in this situation we are sure that commands did not receive any signal, so we send them an interrupt signal immediately.
+//
+// If the command runs in its own process group, it cannot receive the signal along with Terragrunt,
+// so the signal is forwarded to the process group immediately, and the process group is killed if Terragrunt receives an interrupt signal again.
 func (cmd *Cmd) RegisterGracefullyShutdown(ctx context.Context) func() {
 	ctxShutdown, cancelShutdown := context.WithCancel(context.Background())
 
@@ -83,6 +98,12 @@ func (cmd *Cmd) RegisterGracefullyShutdown(ctx context.Context) func() {
 		case <-ctxShutdown.Done():
 		case <-ctx.Done():
 			if cause := new(signal.ContextCanceledError); errors.As(context.Cause(ctx), &cause) && cause.Signal != nil {
+				if cmd.processGroup {
+					cmd.forwardSignalToProcessGroup(ctxShutdown, cause.Signal)
+
+					return
+				}
+
 				cmd.ForwardSignal(ctxShutdown, cause.Signal)
 
 				return
@@ -123,11 +144,42 @@ func (cmd *Cmd) ForwardSignal(ctx context.Context, sig os.Signal) {
 	cmd.SendSignal(sig)
 }
 
-// SendSignal sends the given `sig` to the executed command.
+// forwardSignalToProcessGroup sends the given `sig` to the process group of the executed command immediately,
+// and kills the process group if any interrupt signal is received again, until the given `ctx` becomes `Done`.
+func (cmd *Cmd) forwardSignalToProcessGroup(ctx context.Context, sig os.Signal) {
+	signal.NotifierWithContext(ctx, func(sig os.Signal) {
+		cmd.logger.Warnf("%s signal received again, killing %s", cases.Title(language.English).String(sig.String()), cmd.filename)
+
+		cmd.SendSignal(os.Kill)
+	}, signal.InterruptSignals...)
+
+	cmd.SendSignal(sig)
+}
+
+// SendSignal sends the given `sig` to the executed command, or to its process group if the command runs in its own process group.
 func (cmd *Cmd) SendSignal(sig os.Signal) {
 	cmd.logger.Debugf("%s signal is forwarded to %s", cases.Title(language.English).String(sig.String()), cmd.filename)
 
-	if err := cmd.Process.Signal(sig); err != nil {
+	cmd.sentSignalMu.Lock()
+	cmd.sentSignal = sig
+	cmd.sentSignalMu.Unlock()
+
+	sendFn := cmd.Process.Signal
+	if cmd.processGroup && !cmd.usePTY {
+		sendFn = func(sig os.Signal) error {
+			return signalProcessGroup(cmd.Process, sig)
+		}
+	}
+
+	if err := sendFn(sig); err != nil {
 		cmd.logger.Errorf("Failed to forwarding signal %s to %s: %v", sig, cmd.filename, err)
 	}
 }
+
+// SentSignal returns the last signal sent to the executed command, nil if no signal was sent.
+func (cmd *Cmd) SentSignal() os.Signal {
+	cmd.sentSignalMu.Lock()
+	defer cmd.sentSignalMu.Unlock()
+
+	return cmd.sentSignal
+}
@@ -4,9 +4,11 @@
 package exec_test
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"strconv"
+	"syscall"
 	"testing"
 	"time"
 
@@ -112,3 +114,22 @@ func TestNewSignalsForwarderMultipleUnix(t *testing.T) {
 	assert.LessOrEqual(t, retCode, interrupts, "Subprocess received wrong number of signals")
 	assert.Equal(t, expectedInterrupts, retCode, "Subprocess didn't receive multiple signals")
 }
+
+func TestSendSignalProcessGroupUnix(t *testing.T) {
+	t.Parallel()
+
+	// The shell runs `sleep` as a subprocess, which inherits the stdout pipe, so `Wait` returns only when both are terminated.
+	cmd := exec.Command("sh", "-c", "sleep 30; echo done")
+	cmd.Stdout = new(bytes.Buffer)
+	cmd.Configure(exec.WithProcessGroup(true))
+
+	require.NoError(t, cmd.Start())
+
+	time.Sleep(time.Second)
+	start := time.Now()
+	cmd.SendSignal(syscall.SIGTERM)
+
+	require.Error(t, cmd.Wait())
+	assert.WithinDuration(t, start, time.Now(), 5*time.Second, "Expected the subprocess to receive the signal")
+	assert.Equal(t, syscall.SIGTERM, cmd.SentSignal())
+}
@@ -39,3 +39,10 @@ func WithForwardSignalDelay(delay time.Duration) Option {
 		cmd.forwardSignalDelay = delay
 	}
 }
+
+// WithProcessGroup runs the Cmd in its own process group, so the forwarded signals reach all its subprocesses.
+func WithProcessGroup(state bool) Option {
+	return func(cmd *Cmd) {
+		cmd.processGroup = state
+	}
+}
@@ -0,0 +1,36 @@
+//go:build !windows
+// +build !windows
+
+package exec
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// setProcessGroup makes the command start in its own process group, so it doesn't receive the signals sent to
+// the process group of Terragrunt, e.g. by pressing Ctrl-C in the terminal.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// signalProcessGroup sends the given `sig` to all processes of the process group led by the given process.
+func signalProcessGroup(process *os.Process, sig os.Signal) error {
+	sysSig, ok := sig.(syscall.Signal)
+	if !ok {
+		return process.Signal(sig)
+	}
+
+	if err := syscall.Kill(-process.Pid, sysSig); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
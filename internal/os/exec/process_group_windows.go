@@ -0,0 +1,17 @@
+//go:build windows
+// +build windows
+
+package exec
+
+import (
+	"os"
+	"os/exec"
+)
+
+// For windows, there are no process groups that can be signaled, so the command is run as is.
+func setProcessGroup(_ *exec.Cmd) {}
+
+// For windows, the signal is sent only to the process itself.
+func signalProcessGroup(process *os.Process, sig os.Signal) error {
+	return process.Signal(sig)
+}
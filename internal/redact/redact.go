@@ -0,0 +1,44 @@
+// Package redact provides the patterns of the values that look like secrets, shared by the redaction of the logged
+// command arguments and the `redact` log format option.
+package redact
+
+import "regexp"
+
+// Mask replaces the redacted values.
+const Mask = "***"
+
+// secretValueRegexes match the values that look like secrets regardless of the names they are passed with, such as
+// AWS access keys and bearer tokens.
+var secretValueRegexes = []*regexp.Regexp{ //nolint:gochecknoglobals
+	// AWS access key IDs
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	// tokens in the HTTP authorization headers
+	regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]{8,}=*`),
+	// GitHub personal access and app tokens
+	regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`),
+	// GitLab personal access tokens
+	regexp.MustCompile(`\bglpat-[A-Za-z0-9\-_]{20,}\b`),
+	// Slack tokens
+	regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`),
+	// PEM encoded private keys
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+}
+
+// SecretValueRegexes returns the built-in patterns of the values that look like secrets.
+func SecretValueRegexes() []*regexp.Regexp {
+	return append([]*regexp.Regexp{}, secretValueRegexes...)
+}
+
+// Secrets returns the given string with the substrings matching any of the built-in patterns replaced with `***`.
+func Secrets(str string) string {
+	return ReplaceAll(str, secretValueRegexes...)
+}
+
+// ReplaceAll returns the given string with the substrings matching any of the given patterns replaced with `***`.
+func ReplaceAll(str string, regexes ...*regexp.Regexp) string {
+	for _, regex := range regexes {
+		str = regex.ReplaceAllLiteralString(str, Mask)
+	}
+
+	return str
+}
@@ -134,6 +134,9 @@ type TerragruntOptions struct {
 	// Disable Terragrunt colors
 	DisableLogColors bool
 
+	// Disable Unicode symbols in Terragrunt logs
+	DisableLogUnicode bool
+
 	// Output Terragrunt logs in JSON format
 	JSONLogFormat bool
 
@@ -232,6 +235,17 @@ type TerragruntOptions struct {
 	// Parallelism limits the number of commands to run concurrently during *-all commands
 	Parallelism int
 
+	// MaxOutputSize limits the size in bytes of the stdout and stderr of each command kept in memory, 0 means unlimited.
+	MaxOutputSize int
+
+	// DryRun logs the commands that could change the infrastructure or files instead of running them,
+	// read-only commands, such as `terraform version`, still run.
+	DryRun bool
+
+	// PathPrepend are the directories prepended to the PATH of the executed commands, merged with the inherited value,
+	// so wrapped binaries placed in them, such as a `terraform` wrapper script, are found first.
+	PathPrepend []string
+
 	// Enable check mode, by default it's disabled.
 	Check bool
 
@@ -244,6 +258,9 @@ type TerragruntOptions struct {
 	// If set hclfmt will skip files in given directories.
 	HclExclude []string
 
+	// If set hclfmt will skip files matching the given glob patterns, relative to the working directory.
+	HclExcludeGlobs []string
+
 	// If True then HCL from StdIn must should be formatted.
 	HclFromStdin bool
 
@@ -303,9 +320,81 @@ type TerragruntOptions struct {
 	// Variables for usage in scaffolding.
 	ScaffoldVars []string
 
+	// Variables with JSON values for usage in scaffolding, e.g. `tags={"team":"platform"}`.
+	ScaffoldVarsJSON []string
+
 	// Files with variables to be used in modules scaffolding.
 	ScaffoldVarFiles []string
 
+	// Fail scaffolding instead of warning when the module and template refs mismatch.
+	ScaffoldStrict bool
+
+	// Verify that the commit SHA passed as the module ref exists in the module repository.
+	ScaffoldVerifyRef bool
+
+	// The name of the boilerplate config file in the scaffold template, by default `boilerplate.yml` or `boilerplate.yaml`.
+	ScaffoldConfigFile string
+
+	// The name of the file generated by the default scaffold template, by default `terragrunt.hcl`.
+	ScaffoldTemplateFile string
+
+	// The name of the built-in scaffold template used when the module has no boilerplate template, by default `minimal`.
+	ScaffoldTemplatePreset string
+
+	// Command to run in the working directory after scaffolding completes.
+	ScaffoldPostHook string
+
+	// Disable pinning the scaffolded module to the `Ref` variable or the last release tag.
+	ScaffoldNoRef bool
+
+	// Value of the `Authorization` header sent with HTTP(S) downloads of scaffold modules, templates and var files.
+	ScaffoldAuthHeader string
+
+	// Verify that the resolved scaffold module, template and inputs match the lock in the working directory, instead of scaffolding.
+	ScaffoldVerify bool
+
+	// ARN of the IAM role assumed to download scaffold modules, templates and var files from S3.
+	ScaffoldS3RoleARN string
+
+	// Email of the GCP service account impersonated to download scaffold modules, templates and var files from GCS.
+	ScaffoldGCSServiceAccount string
+
+	// Keep the temporary directories with the downloaded module and the boilerplate files after scaffolding.
+	ScaffoldKeepTemp bool
+
+	// Fail scaffolding if the generated files would overwrite tracked files with uncommitted changes.
+	ScaffoldRespectGit bool
+
+	// Placeholders rendered by scaffold templates in place of the generic TODO of the given required variables.
+	ScaffoldPlaceholders map[string]string
+
+	// Module url rendered as the `source` of the scaffolded config instead of the downloaded module url.
+	ScaffoldSourceOverride string
+
+	// The `<name>=<value>[,<value>...]` variable, the module is scaffolded for each value to the subdirectory named by the value.
+	ScaffoldMatrix string
+
+	// Fail scaffolding if any tf file of the module fails to parse, otherwise such files are skipped with a warning.
+	ScaffoldStrictParse bool
+
+	// Globs of the generated files, relative to the working directory, which are not written by scaffolding.
+	ScaffoldExclude []string
+
+	// Directory the files are generated to, along with the manifest, so that they can be reviewed before they are applied.
+	ScaffoldStageDir string
+
+	// Directory of the previously staged files which are copied to the working directory instead of scaffolding.
+	ScaffoldApplyFrom string
+
+	// YAML file mapping the module source URLs to the refs the scaffolded modules are pinned to.
+	ScaffoldVersionsFile string
+
+	// Read a single file scaffold template from stdin.
+	ScaffoldTemplateFromStdin bool
+
+	// Local directory whose files are copied as is to the scaffolded directory after the template is rendered.
+	ScaffoldIncludeDir string
+
 	// Root directory for graph command.
 	GraphRoot string
 
@@ -462,6 +551,7 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		ErrWriter:                      stderr,
 		MaxFoldersToCheck:              DefaultMaxFoldersToCheck,
 		AutoRetry:                      true,
+		ScaffoldStrictParse:            true,
 		RetryMaxAttempts:               DefaultRetryMaxAttempts,
 		RetrySleepInterval:             DefaultRetrySleepInterval,
 		RetryableErrors:                util.CloneStringList(DefaultRetryableErrors),
@@ -610,11 +700,15 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		UnitsReading:                   opts.UnitsReading,
 		ReadFiles:                      opts.ReadFiles,
 		Parallelism:                    opts.Parallelism,
+		MaxOutputSize:                  opts.MaxOutputSize,
+		DryRun:                         opts.DryRun,
+		PathPrepend:                    opts.PathPrepend,
 		StrictInclude:                  opts.StrictInclude,
 		RunTerragrunt:                  opts.RunTerragrunt,
 		AwsProviderPatchOverrides:      opts.AwsProviderPatchOverrides,
 		HclFile:                        opts.HclFile,
 		HclExclude:                     opts.HclExclude,
+		HclExcludeGlobs:                opts.HclExcludeGlobs,
 		HclFromStdin:                   opts.HclFromStdin,
 		JSONOut:                        opts.JSONOut,
 		JSONLogFormat:                  opts.JSONLogFormat,
@@ -629,13 +723,38 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		TerraformImplementation:        opts.TerraformImplementation,
 		GraphRoot:                      opts.GraphRoot,
 		ScaffoldVars:                   opts.ScaffoldVars,
+		ScaffoldVarsJSON:               opts.ScaffoldVarsJSON,
 		ScaffoldVarFiles:               opts.ScaffoldVarFiles,
+		ScaffoldStrict:                 opts.ScaffoldStrict,
+		ScaffoldVerifyRef:              opts.ScaffoldVerifyRef,
+		ScaffoldConfigFile:             opts.ScaffoldConfigFile,
+		ScaffoldTemplateFile:           opts.ScaffoldTemplateFile,
+		ScaffoldTemplatePreset:         opts.ScaffoldTemplatePreset,
+		ScaffoldPostHook:               opts.ScaffoldPostHook,
+		ScaffoldNoRef:                  opts.ScaffoldNoRef,
+		ScaffoldAuthHeader:             opts.ScaffoldAuthHeader,
+		ScaffoldS3RoleARN:              opts.ScaffoldS3RoleARN,
+		ScaffoldVerify:                 opts.ScaffoldVerify,
+		ScaffoldGCSServiceAccount:      opts.ScaffoldGCSServiceAccount,
+		ScaffoldKeepTemp:               opts.ScaffoldKeepTemp,
+		ScaffoldRespectGit:             opts.ScaffoldRespectGit,
+		ScaffoldPlaceholders:           util.CloneStringMap(opts.ScaffoldPlaceholders),
+		ScaffoldSourceOverride:         opts.ScaffoldSourceOverride,
+		ScaffoldMatrix:                 opts.ScaffoldMatrix,
+		ScaffoldStrictParse:            opts.ScaffoldStrictParse,
+		ScaffoldExclude:                opts.ScaffoldExclude,
+		ScaffoldStageDir:               opts.ScaffoldStageDir,
+		ScaffoldApplyFrom:              opts.ScaffoldApplyFrom,
+		ScaffoldVersionsFile:           opts.ScaffoldVersionsFile,
+		ScaffoldTemplateFromStdin:      opts.ScaffoldTemplateFromStdin,
+		ScaffoldIncludeDir:             opts.ScaffoldIncludeDir,
 		JSONDisableDependentModules:    opts.JSONDisableDependentModules,
 		ProviderCache:                  opts.ProviderCache,
 		ProviderCacheToken:             opts.ProviderCacheToken,
 		ProviderCacheDir:               opts.ProviderCacheDir,
 		ProviderCacheRegistryNames:     opts.ProviderCacheRegistryNames,
 		DisableLogColors:               opts.DisableLogColors,
+		DisableLogUnicode:              opts.DisableLogUnicode,
 		OutputFolder:                   opts.OutputFolder,
 		JSONOutputFolder:               opts.JSONOutputFolder,
 		AuthProviderCmd:                opts.AuthProviderCmd,
@@ -817,10 +936,10 @@ func (opts *TerragruntOptions) CloneReadFiles(readFiles *xsync.MapOf[string, []s
 
 // identifyDefaultWrappedExecutable returns default path used for wrapped executable.
 func identifyDefaultWrappedExecutable() string {
-	if util.IsCommandExecutable(TofuDefaultPath, "-version") {
-		return TofuDefaultPath
+	if command, ok := util.FirstExecutable(context.Background(), [][]string{{TofuDefaultPath, "-version"}, {TerraformDefaultPath, "-version"}}); ok {
+		return command
 	}
-	// fallback to Terraform if tofu is not available
+	// fallback to Terraform if neither is available, so the error refers to it
 	return TerraformDefaultPath
 }
 
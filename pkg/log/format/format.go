@@ -12,10 +12,11 @@ import (
 )
 
 const (
-	BareFormatName     = "bare"
-	PrettyFormatName   = "pretty"
-	JSONFormatName     = "json"
-	KeyValueFormatName = "key-value"
+	BareFormatName       = "bare"
+	PrettyFormatName     = "pretty"
+	JSONFormatName       = "json"
+	JSONObjectFormatName = "json-object"
+	KeyValueFormatName   = "key-value"
 )
 
 func NewBareFormat() Placeholders {
@@ -109,6 +110,29 @@ func NewJSONFormat() Placeholders {
 	}
 }
 
+// NewJSONObjectFormat returns the format emitting each log record as a single JSON object with the stable keys,
+// the values are marshaled after the options of their placeholders are applied.
+func NewJSONObjectFormat() Placeholders {
+	return Placeholders{
+		JSONObject(
+			Time(
+				TimeFormat(RFC3339),
+			),
+			Level(),
+			Field(WorkDirKeyName,
+				PathFormat(ShortRelativePath),
+			),
+			Field(TFPathKeyName,
+				PathFormat(FilenamePath),
+			),
+			Message(
+				PathFormat(RelativePath),
+				Color(DisableColor),
+			),
+		),
+	}
+}
+
 func NewKeyValueFormat() Placeholders {
 	return Placeholders{
 		Time(
@@ -136,10 +160,11 @@ func NewKeyValueFormat() Placeholders {
 
 func ParseFormat(str string) (Placeholders, error) {
 	var presets = map[string]func() Placeholders{
-		BareFormatName:     NewBareFormat,
-		PrettyFormatName:   NewPrettyFormat,
-		JSONFormatName:     NewJSONFormat,
-		KeyValueFormatName: NewKeyValueFormat,
+		BareFormatName:       NewBareFormat,
+		PrettyFormatName:     NewPrettyFormat,
+		JSONFormatName:       NewJSONFormat,
+		JSONObjectFormatName: NewJSONObjectFormat,
+		KeyValueFormatName:   NewKeyValueFormat,
 	}
 
 	for name, formatFn := range presets {
@@ -17,7 +17,9 @@ type Formatter struct {
 	baseDir        string
 	placeholders   placeholders.Placeholders
 	disableColors  bool
+	disableUnicode bool
 	relativePather *options.RelativePather
+	commonPrefix   *options.CommonPrefixState
 	mu             sync.Mutex
 }
 
@@ -25,6 +27,7 @@ type Formatter struct {
 func NewFormatter(phs placeholders.Placeholders) *Formatter {
 	return &Formatter{
 		placeholders: phs,
+		commonPrefix: options.NewCommonPrefixState(),
 	}
 }
 
@@ -43,7 +46,9 @@ func (formatter *Formatter) Format(entry *log.Entry) ([]byte, error) {
 		Entry:          entry,
 		BaseDir:        formatter.baseDir,
 		DisableColors:  formatter.disableColors,
+		DisableUnicode: formatter.disableUnicode,
 		RelativePather: formatter.relativePather,
+		CommonPrefix:   formatter.commonPrefix,
 	})
 	if err != nil {
 		return nil, err
@@ -70,6 +75,11 @@ func (formatter *Formatter) DisableColors() {
 	formatter.disableColors = true
 }
 
+// DisableUnicode makes the options displaying symbols, such as `bool-symbol`, fall back to ASCII.
+func (formatter *Formatter) DisableUnicode() {
+	formatter.disableUnicode = true
+}
+
 func (formatter *Formatter) SetBaseDir(baseDir string) error {
 	pather, err := options.NewRelativePather(baseDir)
 	if err != nil {
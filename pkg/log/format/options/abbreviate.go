@@ -0,0 +1,89 @@
+package options
+
+import (
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// AbbreviateOptionName is the option name.
+const AbbreviateOptionName = "abbreviate"
+
+const (
+	abbreviateEntrySep = ","
+	abbreviateValueSep = "="
+)
+
+// AbbreviateValue contains the table of the abbreviations by the full values.
+type AbbreviateValue struct {
+	table map[string]string
+	// foldTable is the table by the lower case values, used for the case-insensitive lookup.
+	foldTable map[string]string
+}
+
+// Parse parses the table in the format `<value>=<short>[,<value>=<short>...]`.
+func (val *AbbreviateValue) Parse(str string) error {
+	var (
+		table     = make(map[string]string)
+		foldTable = make(map[string]string)
+	)
+
+	for _, entry := range strings.Split(str, abbreviateEntrySep) {
+		key, short, ok := strings.Cut(entry, abbreviateValueSep)
+
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return errors.Errorf("incorrect abbreviation: %s, must be in the format <value>=<short>", entry)
+		}
+
+		short = strings.TrimSpace(short)
+		table[key] = short
+
+		// the first of the values differing only in case is used for the case-insensitive lookup
+		if _, ok := foldTable[strings.ToLower(key)]; !ok {
+			foldTable[strings.ToLower(key)] = short
+		}
+	}
+
+	val.table = table
+	val.foldTable = foldTable
+
+	return nil
+}
+
+func (val *AbbreviateValue) Get() *AbbreviateValue {
+	return val
+}
+
+// Lookup returns the abbreviation of the given value. The exact match takes precedence over the case-insensitive one,
+// e.g. with the `info=inf,INFO=INF` table, `INFO` is abbreviated to `INF` and `Info` to `inf`.
+func (val *AbbreviateValue) Lookup(str string) (string, bool) {
+	if short, ok := val.table[str]; ok {
+		return short, true
+	}
+
+	short, ok := val.foldTable[strings.ToLower(str)]
+
+	return short, ok
+}
+
+type AbbreviateOption struct {
+	*CommonOption[*AbbreviateValue]
+}
+
+// Format implements `Option` interface.
+func (option *AbbreviateOption) Format(_ *Data, val any) (any, error) {
+	if short, ok := option.value.Get().Lookup(toString(val)); ok {
+		return short, nil
+	}
+
+	return val, nil
+}
+
+// Abbreviate creates the option to replace the known values with their abbreviations from the table,
+// e.g. `DEBUG` with `DBG`. The values which are not in the table are displayed as is.
+func Abbreviate() Option {
+	return &AbbreviateOption{
+		CommonOption: NewCommonOption(AbbreviateOptionName, &AbbreviateValue{}),
+	}
+}
@@ -0,0 +1,82 @@
+package options
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// AnonymizeOptionName is the option name.
+const AnonymizeOptionName = "anonymize"
+
+const (
+	// DefaultAnonymizeLength is the number of hex characters of the hash displayed when the length is not specified.
+	DefaultAnonymizeLength = 12
+
+	maxAnonymizeLength = sha256.Size * 2
+	anonymizeValueSep  = ":"
+)
+
+// AnonymizeValue contains the salt and the length of the hash.
+type AnonymizeValue struct {
+	salt    string
+	length  int
+	enabled bool
+}
+
+// Parse parses the value in the format `<salt>[:<length>]`.
+func (val *AnonymizeValue) Parse(str string) error {
+	salt, lengthStr, hasLength := strings.Cut(str, anonymizeValueSep)
+
+	length := DefaultAnonymizeLength
+
+	if hasLength {
+		v, err := strconv.Atoi(lengthStr)
+		if err != nil || v < 1 || v > maxAnonymizeLength {
+			return errors.Errorf("incorrect hash length: %s, must be between 1 and %d", lengthStr, maxAnonymizeLength)
+		}
+
+		length = v
+	}
+
+	*val = AnonymizeValue{
+		salt:    salt,
+		length:  length,
+		enabled: true,
+	}
+
+	return nil
+}
+
+func (val *AnonymizeValue) Get() AnonymizeValue {
+	return *val
+}
+
+type AnonymizeOption struct {
+	*CommonOption[AnonymizeValue]
+}
+
+// Format implements `Option` interface.
+func (option *AnonymizeOption) Format(_ *Data, val any) (any, error) {
+	value := option.value.Get()
+
+	str := toString(val)
+
+	if !value.enabled || str == "" {
+		return val, nil
+	}
+
+	hash := sha256.Sum256([]byte(value.salt + str))
+
+	return hex.EncodeToString(hash[:])[:value.length], nil
+}
+
+// Anonymize creates the option to replace the text with a salted hash, so that values can be correlated without being exposed.
+func Anonymize() Option {
+	return &AnonymizeOption{
+		CommonOption: NewCommonOption(AnonymizeOptionName, &AnonymizeValue{}),
+	}
+}
@@ -0,0 +1,115 @@
+package options
+
+import (
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// BoolSymbolOptionName is the option name.
+const BoolSymbolOptionName = "bool-symbol"
+
+// BoolSymbolCheck is the name of the built-in pair of symbols, `✓` and `✗`.
+const BoolSymbolCheck = "check"
+
+const (
+	checkTrueSymbol  = "✓"
+	checkFalseSymbol = "✗"
+
+	// asciiTrueSymbol and asciiFalseSymbol replace the non-ASCII symbols if Unicode is disabled.
+	asciiTrueSymbol  = "yes"
+	asciiFalseSymbol = "no"
+)
+
+// BoolSymbolValue contains the strings displayed in place of the true and false values.
+type BoolSymbolValue struct {
+	trueSymbol  string
+	falseSymbol string
+}
+
+// Parse parses either `check` or the space separated true and false strings, e.g. `yes no`.
+func (val *BoolSymbolValue) Parse(str string) error {
+	if str == BoolSymbolCheck {
+		val.trueSymbol, val.falseSymbol = checkTrueSymbol, checkFalseSymbol
+
+		return nil
+	}
+
+	symbols := strings.Fields(str)
+
+	const symbolsCount = 2
+
+	if len(symbols) != symbolsCount {
+		return errors.Errorf("incorrect option value: %s, expected %s or '<true> <false>'", str, BoolSymbolCheck)
+	}
+
+	val.trueSymbol, val.falseSymbol = symbols[0], symbols[1]
+
+	return nil
+}
+
+func (val *BoolSymbolValue) Get() *BoolSymbolValue {
+	return val
+}
+
+// symbol returns the symbol for the given boolean, the non-ASCII symbols are replaced with `yes` and `no`
+// if Unicode is disabled.
+func (val *BoolSymbolValue) symbol(enabled, disableUnicode bool) string {
+	symbol, asciiSymbol := val.falseSymbol, asciiFalseSymbol
+	if enabled {
+		symbol, asciiSymbol = val.trueSymbol, asciiTrueSymbol
+	}
+
+	if disableUnicode && !isASCII(symbol) {
+		return asciiSymbol
+	}
+
+	return symbol
+}
+
+type BoolSymbolOption struct {
+	*CommonOption[*BoolSymbolValue]
+}
+
+// Format implements `Option` interface.
+func (option *BoolSymbolOption) Format(data *Data, val any) (any, error) {
+	value := option.value.Get()
+
+	if value.trueSymbol == "" {
+		return val, nil
+	}
+
+	enabled, ok := boolValue(val)
+	if !ok {
+		return val, nil
+	}
+
+	return value.symbol(enabled, data != nil && data.DisableUnicode), nil
+}
+
+// boolValue returns the boolean of the `true` and `false` values, case-insensitive, as well as `1` and `0`,
+// the second value is false for the rest of the values.
+func boolValue(val any) (bool, bool) {
+	if val, ok := val.(bool); ok {
+		return val, true
+	}
+
+	str := toString(val)
+
+	switch {
+	case strings.EqualFold(str, "true"), str == "1":
+		return true, true
+	case strings.EqualFold(str, "false"), str == "0":
+		return false, true
+	}
+
+	return false, false
+}
+
+// BoolSymbol creates the option to display the `true` and `false` values, case-insensitive, as well as `1` and `0`,
+// as the given symbols. The other values are displayed as is, and nothing is replaced by default.
+func BoolSymbol() Option {
+	return &BoolSymbolOption{
+		CommonOption: NewCommonOption(BoolSymbolOptionName, &BoolSymbolValue{}),
+	}
+}
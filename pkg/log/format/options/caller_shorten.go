@@ -0,0 +1,84 @@
+package options
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// CallerShortenOptionName is the option name.
+const CallerShortenOptionName = "caller-shorten"
+
+// defaultCallerShortenSegments is the number of the last path segments kept if the option is set to `true`,
+// that is the package directory and the file name.
+const defaultCallerShortenSegments = 2
+
+var callerRegexp = regexp.MustCompile(`^(.+):(\d+)$`)
+
+// CallerShortenValue contains the number of the last path segments to keep.
+type CallerShortenValue int
+
+// Parse parses either the number of the segments or a boolean, `true` keeps the default two segments.
+func (val *CallerShortenValue) Parse(str string) error {
+	if count, err := strconv.Atoi(str); err == nil && count >= 0 {
+		*val = CallerShortenValue(count)
+
+		return nil
+	}
+
+	enabled, err := strconv.ParseBool(str)
+	if err != nil {
+		return errors.Errorf("incorrect option value: %s", str)
+	}
+
+	*val = 0
+
+	if enabled {
+		*val = defaultCallerShortenSegments
+	}
+
+	return nil
+}
+
+func (val *CallerShortenValue) Get() int {
+	return int(*val)
+}
+
+type CallerShortenOption struct {
+	*CommonOption[int]
+}
+
+// Format implements `Option` interface.
+func (option *CallerShortenOption) Format(_ *Data, val any) (any, error) {
+	str := toString(val)
+
+	count := option.value.Get()
+	if count <= 0 {
+		return str, nil
+	}
+
+	match := callerRegexp.FindStringSubmatch(str)
+	if match == nil {
+		return str, nil
+	}
+
+	segments := strings.Split(filepath.ToSlash(match[1]), "/")
+	if count < len(segments) {
+		segments = segments[len(segments)-count:]
+	}
+
+	return strings.Join(segments, "/") + ":" + match[2], nil
+}
+
+// CallerShorten creates the option to display the caller `path:line` with only the given number of the last path
+// segments, e.g. `scaffold/action.go:112`. The values not formatted as `path:line` are displayed as is.
+func CallerShorten(val int) Option {
+	value := CallerShortenValue(val)
+
+	return &CallerShortenOption{
+		CommonOption: NewCommonOption[int](CallerShortenOptionName, &value),
+	}
+}
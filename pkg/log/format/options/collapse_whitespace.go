@@ -0,0 +1,29 @@
+package options
+
+import (
+	"strings"
+)
+
+// CollapseWhitespaceOptionName is the option name.
+const CollapseWhitespaceOptionName = "collapse-whitespace"
+
+type CollapseWhitespaceOption struct {
+	*CommonOption[bool]
+}
+
+// Format implements `Option` interface.
+func (option *CollapseWhitespaceOption) Format(_ *Data, val any) (any, error) {
+	if !option.value.Get() {
+		return val, nil
+	}
+
+	// `strings.Fields` splits the text around each run of Unicode whitespace, such as tabs, newlines or non-breaking spaces.
+	return strings.Join(strings.Fields(toString(val)), " "), nil
+}
+
+// CollapseWhitespace creates the option to replace each run of whitespace with a single space and trim the text.
+func CollapseWhitespace(val bool) Option {
+	return &CollapseWhitespaceOption{
+		CommonOption: NewCommonOption(CollapseWhitespaceOptionName, NewBoolValue(val)),
+	}
+}
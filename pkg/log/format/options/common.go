@@ -81,6 +81,28 @@ func (val *IntValue) Get() int {
 	return int(*val)
 }
 
+type BoolValue bool
+
+func NewBoolValue(val bool) *BoolValue {
+	v := BoolValue(val)
+	return &v
+}
+
+func (val *BoolValue) Parse(str string) error {
+	v, err := strconv.ParseBool(str)
+	if err != nil {
+		return errors.Errorf("incorrect option value: %s", str)
+	}
+
+	*val = BoolValue(v)
+
+	return nil
+}
+
+func (val *BoolValue) Get() bool {
+	return bool(*val)
+}
+
 type MapValue[T comparable] struct {
 	list  map[T]string
 	value T
@@ -0,0 +1,188 @@
+package options
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// CommonPrefixOptionName is the option name.
+const CommonPrefixOptionName = "common-prefix"
+
+// defaultCommonPrefixThreshold is the number of the paths in a row sharing the prefix before it's elided,
+// if the option is set to `true`.
+const defaultCommonPrefixThreshold = 5
+
+// commonPrefixReplacement replaces the elided prefix.
+const commonPrefixReplacement = "." + string(filepath.Separator)
+
+// CommonPrefixState keeps track of the longest common directory of the paths seen during the run. The same instance
+// is shared by all placeholders of the formatter, so the prefix is the same in all path fields, e.g. `%prefix`
+// and `%tf-path`.
+//
+// The logs are streamed, so the prefix has to be decided on the paths seen so far, without knowing the paths of
+// the following records. Computing the prefix over the whole run would require buffering all records until the end
+// of the run which delays the output, instead the paths are displayed as is until the prefix stabilizes, that is
+// it does not change for the given number of the paths in a row. From that point on, the prefix is frozen so that
+// the elided paths of the different records are relative to the same directory, and the paths outside of it,
+// seen later, are displayed as is.
+type CommonPrefixState struct {
+	prefix    string
+	unchanged int
+	stable    bool
+	mu        sync.Mutex
+}
+
+// NewCommonPrefixState returns a new CommonPrefixState instance.
+func NewCommonPrefixState() *CommonPrefixState {
+	return &CommonPrefixState{}
+}
+
+// Elide registers the given absolute path and returns it with the common prefix replaced with `./` once the prefix
+// is shared by the `threshold` paths in a row. The relative paths are returned as is.
+func (state *CommonPrefixState) Elide(path string, threshold int) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+
+	path = filepath.Clean(path)
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if !state.stable {
+		state.register(path)
+
+		if state.unchanged < threshold {
+			return path
+		}
+
+		state.stable = true
+	}
+
+	// eliding the root directory shortens nothing
+	if filepath.Dir(state.prefix) == state.prefix {
+		return path
+	}
+
+	rel, ok := strings.CutPrefix(path, state.prefix+string(filepath.Separator))
+	if !ok {
+		return path
+	}
+
+	return commonPrefixReplacement + rel
+}
+
+// register narrows the prefix down to the common directory with the parent directory of the path,
+// so that at least the last segment of each path is kept.
+func (state *CommonPrefixState) register(path string) {
+	dir := filepath.Dir(path)
+
+	if state.prefix == "" {
+		state.prefix = dir
+		state.unchanged = 1
+
+		return
+	}
+
+	prefix := commonDir(state.prefix, dir)
+	if prefix == state.prefix {
+		state.unchanged++
+
+		return
+	}
+
+	state.prefix = prefix
+	state.unchanged = 1
+}
+
+// commonDir returns the longest common directory of the two cleaned absolute paths.
+func commonDir(a, b string) string {
+	sep := string(filepath.Separator)
+
+	for a != b {
+		if len(a) > len(b) {
+			a, b = b, a
+		}
+
+		if strings.HasPrefix(b, strings.TrimSuffix(a, sep)+sep) {
+			return a
+		}
+
+		parent := filepath.Dir(a)
+		if parent == a {
+			return a
+		}
+
+		a = parent
+	}
+
+	return a
+}
+
+// CommonPrefixValue contains the number of the paths in a row sharing the prefix before it's elided.
+type CommonPrefixValue int
+
+// Parse parses either the number of the paths or a boolean, `true` sets the default number of the paths.
+func (val *CommonPrefixValue) Parse(str string) error {
+	if count, err := strconv.Atoi(str); err == nil && count >= 0 {
+		*val = CommonPrefixValue(count)
+
+		return nil
+	}
+
+	enabled, err := strconv.ParseBool(str)
+	if err != nil {
+		return errors.Errorf("incorrect option value: %s", str)
+	}
+
+	*val = 0
+
+	if enabled {
+		*val = defaultCommonPrefixThreshold
+	}
+
+	return nil
+}
+
+func (val *CommonPrefixValue) Get() int {
+	return int(*val)
+}
+
+type CommonPrefixOption struct {
+	*CommonOption[int]
+
+	// state is used if the formatter does not share its own state through `Data`.
+	state *CommonPrefixState
+}
+
+// Format implements `Option` interface.
+func (option *CommonPrefixOption) Format(data *Data, val any) (any, error) {
+	str := toString(val)
+
+	threshold := option.value.Get()
+	if threshold <= 0 {
+		return str, nil
+	}
+
+	state := option.state
+	if data != nil && data.CommonPrefix != nil {
+		state = data.CommonPrefix
+	}
+
+	return state.Elide(str, threshold), nil
+}
+
+// CommonPrefix creates the option to replace the longest common directory of the absolute paths displayed during
+// the run with `./`, once it's shared by the given number of the paths in a row.
+func CommonPrefix(val int) Option {
+	value := CommonPrefixValue(val)
+
+	return &CommonPrefixOption{
+		CommonOption: NewCommonOption[int](CommonPrefixOptionName, &value),
+		state:        NewCommonPrefixState(),
+	}
+}
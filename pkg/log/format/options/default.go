@@ -0,0 +1,24 @@
+package options
+
+// DefaultOptionName is the option name.
+const DefaultOptionName = "default"
+
+type DefaultOption struct {
+	*CommonOption[string]
+}
+
+// Format implements `Option` interface.
+func (option *DefaultOption) Format(_ *Data, val any) (any, error) {
+	if str := toString(val); str == "" {
+		return option.value.Get(), nil
+	}
+
+	return val, nil
+}
+
+// Default creates the option that sets the fallback text for empty content.
+func Default(val string) Option {
+	return &DefaultOption{
+		CommonOption: NewCommonOption(DefaultOptionName, NewStringValue(val)),
+	}
+}
@@ -0,0 +1,89 @@
+package options
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ExtractKVOptionName is the option name.
+const ExtractKVOptionName = "extract"
+
+type ExtractKVOption struct {
+	*CommonOption[string]
+}
+
+// Format implements `Option` interface.
+func (option *ExtractKVOption) Format(_ *Data, val any) (any, error) {
+	key := option.value.Get()
+
+	if key == "" {
+		return val, nil
+	}
+
+	return extractKeyValue(toString(val), key), nil
+}
+
+// extractKeyValue returns the value of the first `key=value` pair with the given key found in the given text.
+// Values enclosed in double or single quotes may contain spaces, quotes inside them can be escaped with a backslash.
+func extractKeyValue(str, key string) string {
+	prefix := key + "="
+
+	for idx := 0; idx < len(str); {
+		pos := strings.Index(str[idx:], prefix)
+		if pos < 0 {
+			break
+		}
+
+		start := idx + pos
+		idx = start + len(prefix)
+
+		// Make sure that the key is not a suffix of another key, e.g. `name` in `filename=...`.
+		if start > 0 && !unicode.IsSpace(rune(str[start-1])) {
+			continue
+		}
+
+		return readKeyValue(str[idx:])
+	}
+
+	return ""
+}
+
+func readKeyValue(str string) string {
+	if str == "" {
+		return ""
+	}
+
+	quote := str[0]
+
+	if quote != '"' && quote != '\'' {
+		if end := strings.IndexFunc(str, unicode.IsSpace); end >= 0 {
+			return str[:end]
+		}
+
+		return str
+	}
+
+	var value strings.Builder
+
+	for i := 1; i < len(str); i++ {
+		switch c := str[i]; {
+		case c == '\\' && i+1 < len(str) && (str[i+1] == quote || str[i+1] == '\\'):
+			i++
+			value.WriteByte(str[i])
+		case c == quote:
+			return value.String()
+		default:
+			value.WriteByte(c)
+		}
+	}
+
+	// The closing quote is missing, so the rest of the text is the value.
+	return value.String()
+}
+
+// ExtractKV creates the option to display only the value of the given key from `key=value` pairs in the text.
+func ExtractKV(key string) Option {
+	return &ExtractKVOption{
+		CommonOption: NewCommonOption(ExtractKVOptionName, NewStringValue(key)),
+	}
+}
@@ -0,0 +1,59 @@
+package options
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FlattenOptionName is the option name.
+const FlattenOptionName = "flatten"
+
+// DefaultFlattenSeparator replaces the line breaks if the option is enabled with `flatten=true`.
+const DefaultFlattenSeparator = " ⏎ "
+
+type FlattenOption struct {
+	*CommonOption[string]
+}
+
+// Format implements `Option` interface.
+func (option *FlattenOption) Format(_ *Data, val any) (any, error) {
+	separator := option.value.Get()
+	if separator == "" {
+		return val, nil
+	}
+
+	// `\r\n` goes first, so that Windows line breaks are replaced with a single separator.
+	return strings.NewReplacer("\r\n", separator, "\n", separator).Replace(toString(val)), nil
+}
+
+// FlattenValue is the separator replacing the line breaks, `true` and `false` enable the default separator
+// and disable the option respectively.
+type FlattenValue struct {
+	*StringValue
+}
+
+func NewFlattenValue(val string) *FlattenValue {
+	return &FlattenValue{
+		StringValue: NewStringValue(val),
+	}
+}
+
+func (val *FlattenValue) Parse(str string) error {
+	if enabled, err := strconv.ParseBool(str); err == nil {
+		str = ""
+
+		if enabled {
+			str = DefaultFlattenSeparator
+		}
+	}
+
+	return val.StringValue.Parse(str)
+}
+
+// Flatten creates the option to replace the line breaks with the given separator, so that multiline text, such as
+// a stack trace, is displayed on a single line.
+func Flatten(separator string) Option {
+	return &FlattenOption{
+		CommonOption: NewCommonOption(FlattenOptionName, NewFlattenValue(separator)),
+	}
+}
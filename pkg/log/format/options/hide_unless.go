@@ -0,0 +1,120 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// HideUnlessOptionName is the option name.
+const HideUnlessOptionName = "hide-unless"
+
+const (
+	// hideUnlessLevelField is the name of the condition field matching the level of the log record.
+	hideUnlessLevelField = "level"
+
+	hideUnlessFieldSep = "="
+	hideUnlessValueSep = "|"
+)
+
+// HideUnlessValue contains the condition the content is displayed under.
+type HideUnlessValue struct {
+	field string
+	// values are the lowercased values of the field under which the content is displayed,
+	// if empty, the content is displayed if the field is set.
+	values []string
+}
+
+// Parse parses the condition `<field>[=<value>[|<value>...]]`, e.g. `level=debug|trace`.
+func (val *HideUnlessValue) Parse(str string) error {
+	field, values, hasValues := strings.Cut(str, hideUnlessFieldSep)
+
+	if field = strings.TrimSpace(field); field == "" {
+		return errors.Errorf("incorrect option value: %s, expected '<field>=<value>[|<value>...]'", str)
+	}
+
+	val.field, val.values = field, nil
+
+	if !hasValues {
+		return nil
+	}
+
+	for _, value := range strings.Split(values, hideUnlessValueSep) {
+		if value = strings.TrimSpace(value); value == "" {
+			return errors.Errorf("incorrect option value: %s, empty value of field %s", str, field)
+		}
+
+		val.values = append(val.values, strings.ToLower(value))
+	}
+
+	return nil
+}
+
+func (val *HideUnlessValue) Get() *HideUnlessValue {
+	return val
+}
+
+// fieldValues returns the values of the condition field of the log record, the level is matched by both its full
+// and short names, e.g. `debug` and `dbg`.
+func (val *HideUnlessValue) fieldValues(data *Data) []string {
+	if data == nil || data.Entry == nil {
+		return nil
+	}
+
+	if val.field == hideUnlessLevelField {
+		return []string{strings.ToLower(data.Level.FullName()), strings.ToLower(data.Level.ShortName())}
+	}
+
+	if fieldValue, ok := data.Fields[val.field]; ok && fieldValue != nil {
+		if str := fmt.Sprintf("%v", fieldValue); str != "" {
+			return []string{strings.ToLower(str)}
+		}
+	}
+
+	return nil
+}
+
+// matches returns true if the condition field of the log record has any of the values, or is set at all.
+func (val *HideUnlessValue) matches(data *Data) bool {
+	fieldValues := val.fieldValues(data)
+
+	if len(val.values) == 0 {
+		return len(fieldValues) > 0
+	}
+
+	for _, fieldValue := range fieldValues {
+		for _, value := range val.values {
+			if fieldValue == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type HideUnlessOption struct {
+	*CommonOption[*HideUnlessValue]
+}
+
+// Format implements `Option` interface.
+func (option *HideUnlessOption) Format(data *Data, val any) (any, error) {
+	value := option.value.Get()
+
+	if value.field == "" || value.matches(data) {
+		return val, nil
+	}
+
+	// the empty content stops the rest of the options, so the prefix and the suffix are not displayed either
+	return "", nil
+}
+
+// HideUnless creates the option to hide the content entirely, unless the given field of the log record has any of
+// the given values, e.g. `%tf-path(hide-unless='level=debug|trace')`. The condition is set with `ParseValue`,
+// the content is always displayed by default.
+func HideUnless() Option {
+	return &HideUnlessOption{
+		CommonOption: NewCommonOption(HideUnlessOptionName, &HideUnlessValue{}),
+	}
+}
@@ -0,0 +1,98 @@
+package options
+
+import (
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// LevelColorOptionName is the option name.
+const LevelColorOptionName = "level-color"
+
+const (
+	levelColorPairSep  = ","
+	levelColorValueSep = "="
+)
+
+type LevelColorOption struct {
+	*CommonOption[string]
+	// levelColors maps lowercased level names to their colors.
+	levelColors    map[string]ColorValue
+	compiledColors compiledColorScheme
+}
+
+// Format implements `Option` interface.
+func (option *LevelColorOption) Format(data *Data, val any) (any, error) {
+	if len(option.levelColors) == 0 || data.DisableColors || data.Entry == nil {
+		return val, nil
+	}
+
+	value, ok := option.levelColors[strings.ToLower(data.Level.FullName())]
+	if !ok {
+		if value, ok = option.levelColors[strings.ToLower(data.Level.ShortName())]; !ok {
+			return val, nil
+		}
+	}
+
+	str := toString(val)
+
+	if colorFn, ok := option.compiledColors[value]; ok {
+		str = colorFn(str)
+	}
+
+	return str, nil
+}
+
+// ParseValue implements `Option` interface.
+func (option *LevelColorOption) ParseValue(str string) error {
+	levelColors, err := parseLevelColors(str)
+	if err != nil {
+		return err
+	}
+
+	if err := option.CommonOption.ParseValue(str); err != nil {
+		return err
+	}
+
+	option.levelColors = levelColors
+
+	return nil
+}
+
+// parseLevelColors parses a mapping in the format `<level>=<color>[,<level>=<color>...]`.
+func parseLevelColors(str string) (map[string]ColorValue, error) {
+	levelColors := make(map[string]ColorValue)
+
+	for _, pair := range strings.Split(str, levelColorPairSep) {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		level, colorName, ok := strings.Cut(pair, levelColorValueSep)
+		if !ok {
+			return nil, errors.Errorf("incorrect level color: %s, must be in the format <level>=<color>", pair)
+		}
+
+		color := colorList
+
+		if err := color.Parse(strings.TrimSpace(colorName)); err != nil {
+			return nil, err
+		}
+
+		levelColors[strings.ToLower(strings.TrimSpace(level))] = color.Get()
+	}
+
+	return levelColors, nil
+}
+
+// LevelColor creates the option to set the color of text depending on the log level.
+func LevelColor(val string) Option {
+	levelColors, _ := parseLevelColors(val)
+
+	return &LevelColorOption{
+		CommonOption:   NewCommonOption(LevelColorOptionName, NewStringValue(val)),
+		levelColors:    levelColors,
+		compiledColors: colorScheme.Compile(),
+	}
+}
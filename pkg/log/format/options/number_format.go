@@ -0,0 +1,117 @@
+package options
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NumberFormatOptionName is the option name.
+const NumberFormatOptionName = "number-format"
+
+const (
+	NoneNumberFormat NumberFormatValue = iota
+	GroupingNumberFormat
+	BytesNumberFormat
+)
+
+var numberFormatList = NewMapValue(map[NumberFormatValue]string{ //nolint:gochecknoglobals
+	GroupingNumberFormat: "grouping",
+	BytesNumberFormat:    "bytes",
+})
+
+const (
+	// numberGroupSize is the number of digits between the grouping separators.
+	numberGroupSize = 3
+	// numberGroupSeparator separates the groups of digits, e.g. `1,234,567`.
+	numberGroupSeparator = ','
+
+	// bytesUnitSize is the ratio of the adjacent binary byte units.
+	bytesUnitSize = 1024
+)
+
+// bytesUnits are the binary byte units following `B`.
+var bytesUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"} //nolint:gochecknoglobals
+
+type NumberFormatValue byte
+
+type NumberFormatOption struct {
+	*CommonOption[NumberFormatValue]
+}
+
+// Format implements `Option` interface.
+func (option *NumberFormatOption) Format(_ *Data, val any) (any, error) {
+	str := toString(val)
+
+	if option.value.Get() == NoneNumberFormat {
+		return str, nil
+	}
+
+	num, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return str, nil
+	}
+
+	switch option.value.Get() {
+	case GroupingNumberFormat:
+		return groupDigits(num), nil
+	case BytesNumberFormat:
+		return formatBytes(num), nil
+	case NoneNumberFormat:
+	}
+
+	return str, nil
+}
+
+// groupDigits returns the number with the groups of thousands separated, e.g. `-1,234,567`.
+func groupDigits(num int64) string {
+	digits := strconv.FormatInt(num, 10)
+
+	sign := ""
+	if num < 0 {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	var sb strings.Builder
+
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%numberGroupSize == 0 {
+			sb.WriteRune(numberGroupSeparator)
+		}
+
+		sb.WriteRune(digit)
+	}
+
+	return sign + sb.String()
+}
+
+// formatBytes returns the number of bytes in the largest binary unit it reaches, with one decimal place,
+// e.g. `1.2 MiB`. The values lower than 1 KiB are returned in bytes, e.g. `512 B`.
+func formatBytes(num int64) string {
+	size := float64(num)
+
+	sign := ""
+	if size < 0 {
+		sign, size = "-", -size
+	}
+
+	if size < bytesUnitSize {
+		return fmt.Sprintf("%s%d B", sign, int64(size))
+	}
+
+	unit := 0
+
+	for size /= bytesUnitSize; size >= bytesUnitSize && unit < len(bytesUnits)-1; size /= bytesUnitSize {
+		unit++
+	}
+
+	return fmt.Sprintf("%s%.1f %s", sign, size, bytesUnits[unit])
+}
+
+// NumberFormat creates the option to format the integer values either with the grouping separators, e.g. `1,234,567`,
+// or as the human-readable byte sizes, e.g. `1.2 MiB`. The values which are not integers are displayed as is.
+func NumberFormat(value NumberFormatValue) Option {
+	return &NumberFormatOption{
+		CommonOption: NewCommonOption(NumberFormatOptionName, numberFormatList.Set(value)),
+	}
+}
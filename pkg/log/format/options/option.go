@@ -30,7 +30,9 @@ type Data struct {
 	*log.Entry
 	BaseDir        string
 	DisableColors  bool
+	DisableUnicode bool
 	RelativePather *RelativePather
+	CommonPrefix   *CommonPrefixState
 	PresetColorFn  func() ColorValue
 }
 
@@ -59,7 +61,7 @@ func (opts Options) Names() []string {
 	return names
 }
 
-// Merge replaces options with the same name and adds new ones to the end.
+// Merge replaces options with the same name, keeping their position in the set, and adds new ones to the end.
 func (opts Options) Merge(withOpts ...Option) Options {
 	for i := range opts {
 		for t := range withOpts {
@@ -75,7 +77,7 @@ func (opts Options) Merge(withOpts ...Option) Options {
 	return append(opts, withOpts...)
 }
 
-// Format returns the formatted value.
+// Format returns the formatted value, applying the options one after another in the order of the set.
 func (opts Options) Format(data *Data, val any) (string, error) {
 	var err error
 
@@ -0,0 +1,40 @@
+package options
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// PathTailOptionName is the option name.
+const PathTailOptionName = "path-tail"
+
+// pathTailPrefix is prepended to the path when its leading segments are cut off.
+const pathTailPrefix = "…" + string(filepath.Separator)
+
+type PathTailOption struct {
+	*CommonOption[int]
+}
+
+// Format implements `Option` interface.
+func (option *PathTailOption) Format(_ *Data, val any) (any, error) {
+	str := toString(val)
+
+	count := option.value.Get()
+	if count <= 0 {
+		return str, nil
+	}
+
+	segments := strings.Split(str, string(filepath.Separator))
+	if count >= len(segments) {
+		return str, nil
+	}
+
+	return pathTailPrefix + strings.Join(segments[len(segments)-count:], string(filepath.Separator)), nil
+}
+
+// PathTail creates the option to display only the given number of the last segments of the path.
+func PathTail(val int) Option {
+	return &PathTailOption{
+		CommonOption: NewCommonOption(PathTailOptionName, NewIntValue(val)),
+	}
+}
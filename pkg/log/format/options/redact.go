@@ -0,0 +1,72 @@
+package options
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/redact"
+)
+
+// RedactOptionName is the option name.
+const RedactOptionName = "redact"
+
+// RedactSecrets is the name of the built-in set of patterns matching the values that look like secrets,
+// such as AWS access keys and bearer tokens, the same set is used to redact the logged command arguments.
+const RedactSecrets = "secrets"
+
+// RedactValue contains the compiled patterns of the substrings to redact.
+type RedactValue struct {
+	regexes []*regexp.Regexp
+}
+
+// Parse parses the patterns separated by spaces, `secrets` stands for the built-in set of patterns.
+// The patterns are compiled once here, instead of on every formatted value.
+func (val *RedactValue) Parse(str string) error {
+	var regexes []*regexp.Regexp
+
+	for _, pattern := range strings.Fields(str) {
+		if pattern == RedactSecrets {
+			regexes = append(regexes, redact.SecretValueRegexes()...)
+			continue
+		}
+
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return errors.Errorf("incorrect pattern: %s, %w", pattern, err)
+		}
+
+		regexes = append(regexes, regex)
+	}
+
+	val.regexes = regexes
+
+	return nil
+}
+
+func (val *RedactValue) Get() *RedactValue {
+	return val
+}
+
+type RedactOption struct {
+	*CommonOption[*RedactValue]
+}
+
+// Format implements `Option` interface.
+func (option *RedactOption) Format(_ *Data, val any) (any, error) {
+	regexes := option.value.Get().regexes
+
+	if len(regexes) == 0 {
+		return val, nil
+	}
+
+	return redact.ReplaceAll(toString(val), regexes...), nil
+}
+
+// Redact creates the option to replace the substrings matching any of the patterns with `***`, anywhere in the value.
+// The patterns are set with `ParseValue`, nothing is redacted by default.
+func Redact() Option {
+	return &RedactOption{
+		CommonOption: NewCommonOption(RedactOptionName, &RedactValue{}),
+	}
+}
@@ -0,0 +1,81 @@
+package options
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// RelativePathOptionName is the option name.
+const RelativePathOptionName = "relative-to"
+
+type RelativePathOption struct {
+	*CommonOption[string]
+	baseDir string
+}
+
+// Format implements `Option` interface.
+func (option *RelativePathOption) Format(_ *Data, val any) (any, error) {
+	if option.baseDir == "" {
+		return val, nil
+	}
+
+	str := toString(val)
+
+	relPath, err := filepath.Rel(option.baseDir, str)
+	if err != nil {
+		return val, nil //nolint:nilerr
+	}
+
+	return relPath, nil
+}
+
+// ParseValue implements `Option` interface.
+func (option *RelativePathOption) ParseValue(str string) error {
+	if err := option.CommonOption.ParseValue(str); err != nil {
+		return err
+	}
+
+	baseDir, err := relativePathBaseDir(option.value.Get())
+	if err != nil {
+		return err
+	}
+
+	option.baseDir = baseDir
+
+	return nil
+}
+
+// relativePathBaseDir expands environment variables in the given base directory.
+// The `.` value is resolved to the current working directory.
+func relativePathBaseDir(dir string) (string, error) {
+	dir = os.ExpandEnv(dir)
+
+	if dir == "" {
+		return "", nil
+	}
+
+	if dir == log.CurDir {
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		return workingDir, nil
+	}
+
+	return filepath.Clean(dir), nil
+}
+
+// RelativeTo creates the option to display paths relative to the given base directory.
+// If the current working directory cannot be determined, the option is disabled.
+func RelativeTo(val string) Option {
+	baseDir, _ := relativePathBaseDir(val)
+
+	return &RelativePathOption{
+		CommonOption: NewCommonOption(RelativePathOptionName, NewStringValue(val)),
+		baseDir:      baseDir,
+	}
+}
@@ -0,0 +1,28 @@
+package options
+
+import (
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// StripColorOptionName is the option name.
+const StripColorOptionName = "strip-color"
+
+type StripColorOption struct {
+	*CommonOption[bool]
+}
+
+// Format implements `Option` interface.
+func (option *StripColorOption) Format(_ *Data, val any) (any, error) {
+	if !option.value.Get() {
+		return val, nil
+	}
+
+	return log.RemoveAllASCISeq(toString(val)), nil
+}
+
+// StripColor creates the option to remove ANSI escape sequences from the text.
+func StripColor(val bool) Option {
+	return &StripColorOption{
+		CommonOption: NewCommonOption(StripColorOptionName, NewBoolValue(val)),
+	}
+}
@@ -97,6 +97,11 @@ func (val TimeFormatValue) Set(v string) *TimeFormatValue {
 }
 
 func (val TimeFormatValue) Value(str string) string {
+	// Named layouts, such as `rfc3339`, are returned as is, otherwise their own characters would be replaced further.
+	if layout, ok := val.list[str]; ok {
+		return layout
+	}
+
 	for _, key := range val.SortedKeys() {
 		str = strings.ReplaceAll(str, key, val.list[key])
 	}
@@ -0,0 +1,111 @@
+package options
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// TimestampFormatOptionName is the option name.
+const TimestampFormatOptionName = "timestamp-format"
+
+const (
+	// UnixTimestamp is the input layout for epoch timestamps in seconds.
+	UnixTimestamp = "unix"
+	// UnixMilliTimestamp is the input layout for epoch timestamps in milliseconds.
+	UnixMilliTimestamp = "unixms"
+
+	timestampLayoutSep = "|"
+)
+
+type TimestampFormatOption struct {
+	*CommonOption[string]
+	inputLayout  string
+	outputLayout string
+}
+
+// Format implements `Option` interface.
+func (option *TimestampFormatOption) Format(_ *Data, val any) (any, error) {
+	if option.outputLayout == "" {
+		return val, nil
+	}
+
+	str := toString(val)
+
+	t, err := parseTimestamp(option.inputLayout, strings.TrimSpace(str))
+	if err != nil {
+		return str, nil //nolint:nilerr
+	}
+
+	return t.Format(option.outputLayout), nil
+}
+
+// ParseValue implements `Option` interface.
+func (option *TimestampFormatOption) ParseValue(str string) error {
+	inputLayout, outputLayout, err := parseTimestampLayouts(str)
+	if err != nil {
+		return err
+	}
+
+	if err := option.CommonOption.ParseValue(str); err != nil {
+		return err
+	}
+
+	option.inputLayout, option.outputLayout = inputLayout, outputLayout
+
+	return nil
+}
+
+// parseTimestampLayouts parses the value in the format `<input-layout>|<output-layout>`.
+func parseTimestampLayouts(str string) (string, string, error) {
+	if str == "" {
+		return "", "", nil
+	}
+
+	inputLayout, outputLayout, ok := strings.Cut(str, timestampLayoutSep)
+	if !ok || inputLayout == "" || outputLayout == "" {
+		return "", "", errors.Errorf("incorrect timestamp format: %s, must be in the format <input-layout>%s<output-layout>", str, timestampLayoutSep)
+	}
+
+	if inputLayout != UnixTimestamp && inputLayout != UnixMilliTimestamp {
+		inputLayout = timeFormatList.Value(inputLayout)
+	}
+
+	return inputLayout, timeFormatList.Value(outputLayout), nil
+}
+
+func parseTimestamp(layout, str string) (time.Time, error) {
+	switch layout {
+	case UnixTimestamp, UnixMilliTimestamp:
+		num, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return time.Time{}, errors.New(err)
+		}
+
+		if layout == UnixMilliTimestamp {
+			return time.UnixMilli(num), nil
+		}
+
+		return time.Unix(num, 0), nil
+	}
+
+	t, err := time.Parse(layout, str)
+	if err != nil {
+		return time.Time{}, errors.New(err)
+	}
+
+	return t, nil
+}
+
+// TimestampFormat creates the option to parse the text as a timestamp and reformat it.
+func TimestampFormat(val string) Option {
+	inputLayout, outputLayout, _ := parseTimestampLayouts(val)
+
+	return &TimestampFormatOption{
+		CommonOption: NewCommonOption(TimestampFormatOptionName, NewStringValue(val)),
+		inputLayout:  inputLayout,
+		outputLayout: outputLayout,
+	}
+}
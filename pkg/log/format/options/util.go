@@ -3,6 +3,7 @@ package options
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 func toString(val any) string {
@@ -15,3 +16,13 @@ func toString(val any) string {
 
 	return fmt.Sprintf("%v", val)
 }
+
+func isASCII(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
@@ -8,15 +8,37 @@ import (
 )
 
 // WithCommonOptions is a set of common options that are used in all placeholders.
+// The options are applied in the order they are declared here, with the given placeholder specific options applied
+// right after the default and the hide-unless options, no matter in what order they are written in the format string.
+// For example, `width` is always applied before `prefix` and `suffix`, so the column width does not include them.
 func WithCommonOptions(opts ...options.Option) options.Options {
+	// The default option goes first, since formatting stops as soon as the content becomes empty,
+	// followed by hide-unless, so the hidden content is not replaced with the default text.
+	opts = append([]options.Option{options.Default(""), options.HideUnless()}, opts...)
+
 	return options.Options(append(opts,
 		options.Content(""),
+		options.ExtractKV(""),
+		options.TimestampFormat(""),
+		options.RelativeTo(""),
+		options.CommonPrefix(0),
+		options.PathTail(0),
+		options.CallerShorten(0),
+		options.NumberFormat(options.NoneNumberFormat),
+		options.BoolSymbol(),
+		options.StripColor(false),
+		options.Flatten(""),
+		options.CollapseWhitespace(false),
+		options.Redact(),
+		options.Abbreviate(),
+		options.Anonymize(),
 		options.Escape(options.NoneEscape),
 		options.Case(options.NoneCase),
 		options.Width(0),
 		options.Align(options.NoneAlign),
 		options.Prefix(""),
 		options.Suffix(""),
+		options.LevelColor(""),
 		options.Color(options.NoneColor),
 	))
 }
@@ -21,7 +21,7 @@ func (field *fieldPlaceholder) Format(data *options.Data) (string, error) {
 		return field.opts.Format(data, val)
 	}
 
-	return "", nil
+	return field.opts.Format(data, "")
 }
 
 // Field creates a placeholder that displays log field value.
@@ -0,0 +1,128 @@
+package placeholders
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log/format/options"
+)
+
+// JSONObjectPlaceholderName is the placeholder name.
+const JSONObjectPlaceholderName = "json-object"
+
+type jsonObject struct {
+	placeholders Placeholders
+}
+
+// Name implements `Placeholder` interface.
+func (object *jsonObject) Name() string {
+	return JSONObjectPlaceholderName
+}
+
+// GetOption implements `Placeholder` interface.
+func (object *jsonObject) GetOption(_ string) (options.Option, error) {
+	return nil, errors.Errorf("placeholder %q has no options, set the options of its values instead", object.Name())
+}
+
+// Format implements `Placeholder` interface.
+func (object *jsonObject) Format(data *options.Data) (string, error) {
+	var (
+		buf  bytes.Buffer
+		keys = make(map[string]bool, len(object.placeholders))
+	)
+
+	buf.WriteByte('{')
+
+	for _, ph := range object.placeholders {
+		if ph.Name() == PlainTextPlaceholderName {
+			continue
+		}
+
+		keys[ph.Name()] = true
+
+		// The value goes through the options of the placeholder, such as `width` or `anonymize`, before it's marshaled.
+		val, err := ph.Format(data)
+		if err != nil {
+			return "", err
+		}
+
+		if val == "" {
+			continue
+		}
+
+		if err := writeJSONObjectKeyValue(&buf, ph.Name(), val); err != nil {
+			return "", err
+		}
+	}
+
+	// The rest of the log fields are added with their structured values, sorted by key names to keep the output stable.
+	fieldNames := make([]string, 0, len(data.Fields))
+
+	for name := range data.Fields {
+		if !keys[name] {
+			fieldNames = append(fieldNames, name)
+		}
+	}
+
+	sort.Strings(fieldNames)
+
+	for _, name := range fieldNames {
+		if err := writeJSONObjectKeyValue(&buf, name, jsonFieldValue(data.Fields[name])); err != nil {
+			return "", err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return buf.String(), nil
+}
+
+func writeJSONObjectKeyValue(buf *bytes.Buffer, key string, val any) error {
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	valJSON, err := json.Marshal(val)
+	if err != nil {
+		// the values which can't be marshaled, such as functions, are written as strings
+		if valJSON, err = json.Marshal(fmt.Sprintf("%v", val)); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	if buf.Len() > 1 {
+		buf.WriteByte(',')
+	}
+
+	buf.Write(keyJSON)
+	buf.WriteByte(':')
+	buf.Write(valJSON)
+
+	return nil
+}
+
+// jsonFieldValue returns the value of the log field to marshal, errors and stringers are marshaled as their texts.
+func jsonFieldValue(val any) any {
+	switch val := val.(type) {
+	case error:
+		return val.Error()
+	case fmt.Stringer:
+		return val.String()
+	}
+
+	return val
+}
+
+// JSONObject creates a placeholder that displays the whole log record as a single JSON object. Each of the given
+// placeholders is formatted with its own options and written as a string value with the placeholder name as the key,
+// in the given order, while the empty values are omitted. The log fields not covered by the placeholders are added
+// after them with their structured values, e.g. `tf-command-args` as an array.
+func JSONObject(phs ...Placeholder) Placeholder {
+	return &jsonObject{
+		placeholders: phs,
+	}
+}
@@ -1,10 +1,13 @@
 package shell
 
 import (
-	"bytes"
 	"context"
+	"io"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/cache"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
@@ -16,9 +19,20 @@ const (
 	gitPrefix = "git::"
 	refsTags  = "refs/tags/"
 
+	// peeledTagSuffix is appended by `git ls-remote` to the annotated tags resolved to the commits they point to.
+	peeledTagSuffix = "^{}"
+
 	tagSplitPart = 2
+
+	gitRepoTagsCacheName = "gitRepoTagsCache"
 )
 
+// A cache of the tags of remote git repositories. Each lookup spawns `git ls-remote` which queries the remote,
+// so this cache speeds up scaffolding multiple modules from the same repository.
+//
+// The cache keys are the repository urls, and the values are the tags of the repository.
+var gitRepoTagsCache = cache.NewCache[[]string](gitRepoTagsCacheName)
+
 // GitTopLevelDir fetches git repository path from passed directory.
 func GitTopLevelDir(ctx context.Context, terragruntOptions *options.TerragruntOptions, path string) (string, error) {
 	runCache := cache.ContextCache[string](ctx, RunCmdCacheContextKey)
@@ -28,29 +42,35 @@ func GitTopLevelDir(ctx context.Context, terragruntOptions *options.TerragruntOp
 		return gitTopLevelDir, nil
 	}
 
-	stdout := bytes.Buffer{}
-	stderr := bytes.Buffer{}
-
-	opts, err := options.NewTerragruntOptionsWithConfigPath(path)
+	cmdOutput, err := RunGitCommandOutput(ctx, terragruntOptions, path, "rev-parse", "--show-toplevel")
 	if err != nil {
 		return "", err
 	}
 
-	opts.Logger = terragruntOptions.Logger.Clone()
-	opts.Env = terragruntOptions.Env
-	opts.Writer = &stdout
-	opts.ErrWriter = &stderr
+	terragruntOptions.Logger.Debugf("git show-toplevel result: %s", cmdOutput)
+	runCache.Put(ctx, cacheKey, cmdOutput)
+
+	return cmdOutput, nil
+}
 
-	cmd, err := RunShellCommandWithOutput(ctx, opts, path, true, false, "git", "rev-parse", "--show-toplevel")
+// RunGitCommandOutput runs git in the given directory with the environment and the PATH of the options, and returns
+// its stdout with the leading and trailing whitespace trimmed. The output is not logged, the stderr is only a part of
+// the `ProcessExecutionError` returned if the command fails.
+func RunGitCommandOutput(ctx context.Context, opts *options.TerragruntOptions, dir string, args ...string) (string, error) {
+	gitOpts, err := opts.Clone(opts.TerragruntConfigPath)
 	if err != nil {
 		return "", err
 	}
 
-	cmdOutput := strings.TrimSpace(cmd.Stdout.String())
-	terragruntOptions.Logger.Debugf("git show-toplevel result: \n%v\n%v\n%v\n", stdout.String(), stderr.String(), cmdOutput)
-	runCache.Put(ctx, cacheKey, cmdOutput)
+	gitOpts.Writer = io.Discard
+	gitOpts.ErrWriter = io.Discard
 
-	return cmdOutput, nil
+	output, err := RunShellCommandWithOutput(ctx, gitOpts, dir, true, false, "git", args...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(output.Stdout.String()), nil
 }
 
 // GitRepoTags fetches git repository tags from passed url.
@@ -59,27 +79,14 @@ func GitRepoTags(ctx context.Context, opts *options.TerragruntOptions, gitRepo *
 	// remove git:: part if present
 	repoPath = strings.TrimPrefix(repoPath, gitPrefix)
 
-	stdout := bytes.Buffer{}
-	stderr := bytes.Buffer{}
-
-	gitOpts, err := options.NewTerragruntOptionsWithConfigPath(opts.WorkingDir)
-	if err != nil {
-		return nil, err
-	}
-
-	gitOpts.Logger = opts.Logger.Clone()
-	gitOpts.Env = opts.Env
-	gitOpts.Writer = &stdout
-	gitOpts.ErrWriter = &stderr
-
-	output, err := RunShellCommandWithOutput(ctx, opts, opts.WorkingDir, true, false, "git", "ls-remote", "--tags", repoPath)
+	output, err := RunGitCommandOutput(ctx, opts, opts.WorkingDir, "ls-remote", "--tags", repoPath)
 	if err != nil {
 		return nil, errors.New(err)
 	}
 
 	var tags []string
 
-	tagLines := strings.Split(output.Stdout.String(), "\n")
+	tagLines := strings.Split(output, "\n")
 
 	for _, line := range tagLines {
 		fields := strings.Fields(line)
@@ -91,46 +98,238 @@ func GitRepoTags(ctx context.Context, opts *options.TerragruntOptions, gitRepo *
 	return tags, nil
 }
 
-// GitLastReleaseTag fetches git repository last release tag.
-func GitLastReleaseTag(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL) (string, error) {
-	tags, err := GitRepoTags(ctx, opts, gitRepo)
+// GitCommitExists checks whether the commit with the passed SHA exists in the git repository at the passed url.
+// The refs advertised by the remote are checked first, and if none of them points to the commit, the commit is
+// fetched into a temporary repository, since only the remote can tell whether it knows an arbitrary commit.
+func GitCommitExists(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL, sha string) (bool, error) {
+	repoPath := strings.TrimPrefix(gitRepo.String(), gitPrefix)
+
+	output, err := RunShellCommandWithOutput(ctx, opts, opts.WorkingDir, true, false, "git", "ls-remote", repoPath)
 	if err != nil {
-		return "", err
+		return false, errors.New(err)
+	}
+
+	for _, line := range strings.Split(output.Stdout.String(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= tagSplitPart && strings.EqualFold(fields[0], sha) {
+			return true, nil
+		}
+	}
+
+	tempDir, err := os.MkdirTemp("", "git-commit-exists")
+	if err != nil {
+		return false, errors.New(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			opts.Logger.Warnf("Failed to remove temporary directory %s: %v", tempDir, err)
+		}
+	}()
+
+	if _, err := RunShellCommandWithOutput(ctx, opts, tempDir, true, false, "git", "init", "--quiet"); err != nil {
+		return false, errors.New(err)
+	}
+
+	// the remote is known to be reachable at this point, so a failed fetch means that the commit does not exist
+	if _, err := RunShellCommandWithOutput(ctx, opts, tempDir, true, false, "git", "fetch", "--quiet", "--depth=1", "--filter=tree:0", repoPath, sha); err != nil {
+		opts.Logger.Debugf("Failed to fetch commit %s from %s: %v", sha, repoPath, err)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// GitRefCommit returns the SHA of the commit the ref, a tag or a branch, of the git repository at the passed url points to.
+// Annotated tags are resolved to the commit they point to, instead of the tag object.
+func GitRefCommit(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL, ref string) (string, error) {
+	repoPath := strings.TrimPrefix(gitRepo.String(), gitPrefix)
+
+	output, err := RunGitCommandOutput(ctx, opts, opts.WorkingDir, "ls-remote", repoPath, ref, ref+peeledTagSuffix)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	var commit string
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < tagSplitPart {
+			continue
+		}
+
+		// the peeled tag points to the commit, while the tag itself points to the annotated tag object
+		if strings.HasSuffix(fields[1], peeledTagSuffix) {
+			return fields[0], nil
+		}
+
+		if commit == "" {
+			commit = fields[0]
+		}
+	}
+
+	if commit == "" {
+		return "", errors.Errorf("ref %s is not found in %s", ref, repoPath)
+	}
+
+	return commit, nil
+}
+
+// GitTagDate returns the date of the commit the tag of the git repository at the passed url points to.
+// Since the remote doesn't advertise the dates of its refs, the tag is fetched into a temporary repository.
+func GitTagDate(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL, tag string) (time.Time, error) {
+	repoPath := strings.TrimPrefix(gitRepo.String(), gitPrefix)
+
+	tempDir, err := os.MkdirTemp("", "git-tag-date")
+	if err != nil {
+		return time.Time{}, errors.New(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			opts.Logger.Warnf("Failed to remove temporary directory %s: %v", tempDir, err)
+		}
+	}()
+
+	if _, err := RunGitCommandOutput(ctx, opts, tempDir, "init", "--quiet"); err != nil {
+		return time.Time{}, errors.New(err)
+	}
+
+	if _, err := RunGitCommandOutput(ctx, opts, tempDir, "fetch", "--quiet", "--depth=1", "--filter=tree:0", repoPath, refsTags+strings.TrimPrefix(tag, refsTags)); err != nil {
+		return time.Time{}, errors.New(err)
+	}
+
+	output, err := RunGitCommandOutput(ctx, opts, tempDir, "log", "-1", "--format=%ct", "FETCH_HEAD")
+	if err != nil {
+		return time.Time{}, errors.New(err)
+	}
+
+	timestamp, err := strconv.ParseInt(output, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.New(err)
+	}
+
+	return time.Unix(timestamp, 0), nil
+}
+
+// ReleaseTagOption is a function that configures the lookup of the last release tag.
+type ReleaseTagOption func(*releaseTagFilter)
+
+type releaseTagFilter struct {
+	prefix            string
+	excludePrerelease bool
+	disableCache      bool
+}
+
+// WithPrerelease sets whether the lookup of the last release tag considers prerelease tags, e.g. `v2.0.0-rc.1`.
+// Without the option, all semver tags are considered, including prerelease tags.
+func WithPrerelease(allowPrerelease bool) ReleaseTagOption {
+	return func(filter *releaseTagFilter) {
+		filter.excludePrerelease = !allowPrerelease
+	}
+}
+
+// WithTagPrefix makes the lookup of the last release tag consider only tags starting with the passed prefix,
+// e.g. `vpc/` for tags like `vpc/v1.4.0`. The prefix is stripped before the semver comparison.
+func WithTagPrefix(prefix string) ReleaseTagOption {
+	return func(filter *releaseTagFilter) {
+		filter.prefix = prefix
+	}
+}
+
+// WithoutCache makes the lookup of the last release tag always query the remote repository, instead of reusing
+// the tags fetched by previous lookups of the same repository.
+func WithoutCache() ReleaseTagOption {
+	return func(filter *releaseTagFilter) {
+		filter.disableCache = true
+	}
+}
+
+// GitLastReleaseTag fetches git repository last release tag.
+// The repository tags are cached for the lifetime of the process, unless the `WithoutCache` option is passed.
+func GitLastReleaseTag(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL, tagOpts ...ReleaseTagOption) (string, error) {
+	filter := &releaseTagFilter{}
+
+	for _, opt := range tagOpts {
+		opt(filter)
+	}
+
+	cacheKey := gitRepo.String()
+
+	var (
+		tags  []string
+		found bool
+	)
+
+	if !filter.disableCache {
+		tags, found = gitRepoTagsCache.Get(ctx, cacheKey)
+	}
+
+	if !found {
+		var err error
+
+		if tags, err = GitRepoTags(ctx, opts, gitRepo); err != nil {
+			return "", err
+		}
+
+		if !filter.disableCache {
+			gitRepoTagsCache.Put(ctx, cacheKey, tags)
+		}
 	}
 
 	if len(tags) == 0 {
 		return "", nil
 	}
 
-	return LastReleaseTag(tags), nil
+	return LastReleaseTag(tags, tagOpts...), nil
 }
 
 // LastReleaseTag returns last release tag from passed tags slice.
-func LastReleaseTag(tags []string) string {
-	semverTags := extractSemVerTags(tags)
+// Prerelease tags are considered too, unless they are excluded with `WithPrerelease(false)`.
+func LastReleaseTag(tags []string, tagOpts ...ReleaseTagOption) string {
+	filter := &releaseTagFilter{}
+
+	for _, opt := range tagOpts {
+		opt(filter)
+	}
+
+	semverTags := extractSemVerTags(tags, filter)
 	if len(semverTags) == 0 {
 		return ""
 	}
 	// find last semver tag
-	lastVersion := semverTags[0]
-	for _, ver := range semverTags {
-		if ver.GreaterThanOrEqual(lastVersion) {
-			lastVersion = ver
+	lastTag := semverTags[0]
+	for _, tag := range semverTags {
+		if tag.version.GreaterThanOrEqual(lastTag.version) {
+			lastTag = tag
 		}
 	}
 
-	return lastVersion.Original()
+	return lastTag.name
+}
+
+// semVerTag is a git tag along with the semantic version it refers to.
+type semVerTag struct {
+	version *version.Version
+	name    string
 }
 
 // extractSemVerTags - extract semver tags from passed tags slice.
-func extractSemVerTags(tags []string) []*version.Version {
-	var semverTags []*version.Version
+func extractSemVerTags(tags []string, filter *releaseTagFilter) []semVerTag {
+	var semverTags []semVerTag
 
 	for _, tag := range tags {
-		t := strings.TrimPrefix(tag, refsTags)
-		if v, err := version.NewVersion(t); err == nil {
+		name := strings.TrimPrefix(tag, refsTags)
+		if !strings.HasPrefix(name, filter.prefix) {
+			continue
+		}
+
+		if v, err := version.NewVersion(strings.TrimPrefix(name, filter.prefix)); err == nil {
+			if v.Prerelease() != "" && filter.excludePrerelease {
+				continue
+			}
 			// consider only semver tags
-			semverTags = append(semverTags, v)
+			semverTags = append(semverTags, semVerTag{version: v, name: name})
 		}
 	}
 
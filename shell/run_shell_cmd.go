@@ -45,6 +45,9 @@ const (
 	tfLogMsgPrefix = "TF_LOG: "
 
 	logMsgSeparator = "\n"
+
+	// inputDisabledFlag disables the interactive input of `tofu`/`terraform` commands.
+	inputDisabledFlag = "-input=false"
 )
 
 // Commands that implement a REPL need a pseudo TTY when run as a subprocess in order for the readline properties to be
@@ -71,6 +74,10 @@ func RunTerraformCommandWithOutput(ctx context.Context, opts *options.Terragrunt
 
 	output, err := RunShellCommandWithOutput(ctx, opts, "", false, needsPTY, opts.TerraformPath, args...)
 
+	if err != nil && util.ListContainsElement(args, terraform.FlagNameJSON) {
+		err = withTerraformDiagnostics(err)
+	}
+
 	if err != nil && util.ListContainsElement(args, terraform.FlagNameDetailedExitCode) {
 		code, _ := util.GetExitCode(err)
 		if exitCode := DetailedExitCodeFromContext(ctx); exitCode != nil {
@@ -85,6 +92,31 @@ func RunTerraformCommandWithOutput(ctx context.Context, opts *options.Terragrunt
 	return output, err
 }
 
+// withTerraformDiagnostics replaces the `ProcessExecutionError` of the command run with the `-json` flag
+// with `TerraformDiagnosticsError`, if the diagnostics can be parsed from its output. The diagnostics are looked up
+// in stderr first, and then in stdout, where most of the commands print their JSON messages.
+// Otherwise, the error is returned as is, with the raw stderr.
+func withTerraformDiagnostics(err error) error {
+	var processErr util.ProcessExecutionError
+	if !errors.As(err, &processErr) {
+		return err
+	}
+
+	diagnostics := util.ParseTerraformDiagnostics(processErr.Output.Stderr.String())
+	if len(diagnostics) == 0 {
+		diagnostics = util.ParseTerraformDiagnostics(processErr.Output.Stdout.String())
+	}
+
+	if len(diagnostics) == 0 {
+		return err
+	}
+
+	return errors.New(util.TerraformDiagnosticsError{
+		ProcessExecutionError: processErr,
+		Diagnostics:           diagnostics,
+	})
+}
+
 // RunShellCommand runs the given shell command.
 func RunShellCommand(ctx context.Context, opts *options.TerragruntOptions, command string, args ...string) error {
 	_, err := RunShellCommandWithOutput(ctx, opts, "", false, false, command, args...)
@@ -121,6 +153,12 @@ func RunShellCommandWithOutput(
 		commandDir = opts.WorkingDir
 	}
 
+	if opts.DryRun && !util.IsReadOnlyCommand(command, args...) {
+		opts.Logger.Infof("Dry run, skipping command in %s: %s %s", commandDir, command, strings.Join(args, " "))
+
+		return &output, nil
+	}
+
 	err := telemetry.Telemetry(ctx, opts, "run_"+command, map[string]interface{}{
 		"command": command,
 		"args":    fmt.Sprintf("%v", args),
@@ -165,14 +203,17 @@ func RunShellCommandWithOutput(
 		}
 
 		var (
-			cmdStderr = io.MultiWriter(errWriter, &output.Stderr)
-			cmdStdout = io.MultiWriter(outWriter, &output.Stdout)
+			stdoutBuffer = util.NewTruncatingWriter(&output.Stdout, opts.MaxOutputSize)
+			stderrBuffer = util.NewTruncatingWriter(&output.Stderr, opts.MaxOutputSize)
+
+			cmdStderr = io.MultiWriter(errWriter, stderrBuffer)
+			cmdStdout = io.MultiWriter(outWriter, stdoutBuffer)
 		)
 
 		if suppressStdout {
 			opts.Logger.Debugf("Command output will be suppressed.")
 
-			cmdStdout = io.MultiWriter(&output.Stdout)
+			cmdStdout = io.MultiWriter(stdoutBuffer)
 		}
 
 		if command == opts.TerraformPath {
@@ -202,18 +243,27 @@ func RunShellCommandWithOutput(
 			opts.Logger.Debugf("Engine is not enabled, running command directly in %s", commandDir)
 		}
 
-		cmd := exec.Command(command, args...)
+		// The PATH is overridden only for this command, so the wrapped binaries in the prepended directories are found first.
+		env := util.EnvWithPathPrepend(opts.Env, opts.PathPrepend)
+
+		cmd := exec.Command(util.LookPathIn(opts.PathPrepend, command), args...)
 		cmd.Dir = commandDir
 		cmd.Stdout = cmdStdout
 		cmd.Stderr = cmdStderr
 		cmd.Configure(
 			exec.WithLogger(opts.Logger),
 			exec.WithUsePTY(needsPTY),
-			exec.WithEnv(opts.Env),
+			exec.WithEnv(env),
 			exec.WithForwardSignalDelay(SignalForwardingDelay),
+			exec.WithProcessGroup(!needsPTY && isNonInteractiveCommand(args)),
 		)
 
+		startTime := time.Now()
+
 		if err := cmd.Start(); err != nil { //nolint:contextcheck
+			err = util.WithContextError(ctx, err)
+			util.ObserveCommand(startTime, cmd.Dir, command, args, err)
+
 			err = util.ProcessExecutionError{
 				Err:        err,
 				Args:       args,
@@ -221,19 +271,31 @@ func RunShellCommandWithOutput(
 				WorkingDir: cmd.Dir,
 			}
 
-			return errors.New(err)
+			// E2BIG is explained, since the raw errno doesn't tell that there are too many `-var-file` or `-target` args
+			return util.NewArgListTooLongError(errors.New(err), env)
 		}
 
 		cancelShutdown := cmd.RegisterGracefullyShutdown(ctx)
 		defer cancelShutdown()
 
-		if err := cmd.Wait(); err != nil {
+		// the command is interrupted once the context is canceled, so the error is reported as the cancellation
+		err := util.WithContextError(ctx, cmd.Wait())
+
+		util.ObserveCommand(startTime, cmd.Dir, command, args, err)
+
+		output.Truncated = stdoutBuffer.Truncated() || stderrBuffer.Truncated()
+		if output.Truncated {
+			opts.Logger.Warnf("The output of %s exceeded the max output size of %d bytes and was truncated", command, opts.MaxOutputSize)
+		}
+
+		if err != nil {
 			err = util.ProcessExecutionError{
 				Err:        err,
 				Args:       args,
 				Command:    command,
 				Output:     output,
 				WorkingDir: cmd.Dir,
+				Signal:     cmd.SentSignal(),
 			}
 
 			return errors.New(err)
@@ -245,6 +307,13 @@ func RunShellCommandWithOutput(
 	return &output, err
 }
 
+// isNonInteractiveCommand returns true if the command doesn't read the user input from the terminal, either because
+// the stdin is not a terminal or the input is disabled with `-input=false`, e.g. by `run-all`. Only such commands can run
+// in their own process group, since reading from the terminal by a background process group stops the process.
+func isNonInteractiveCommand(args []string) bool {
+	return util.ListContainsElement(args, inputDisabledFlag) || !isatty.IsTerminal(os.Stdin.Fd())
+}
+
 // isTerraformCommandThatNeedsPty returns true if the sub command of terraform we are running requires a pty.
 func isTerraformCommandThatNeedsPty(args []string) (bool, error) {
 	if len(args) == 0 || !util.ListContainsElement(terraformCommandsThatNeedPty, args[0]) {
@@ -3,11 +3,18 @@ package shell_test
 import (
 	"bytes"
 	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/cache"
 	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -75,6 +82,20 @@ func TestLastReleaseTag(t *testing.T) {
 	assert.Equal(t, "v20.1.2", lastTag)
 }
 
+func TestLastReleaseTagPrerelease(t *testing.T) {
+	t.Parallel()
+	var tags = []string{
+		"refs/tags/v1.0.0",
+		"refs/tags/v1.5.0",
+		"refs/tags/v2.0.0-rc.1",
+		"refs/tags/v2.0.0-beta.1",
+	}
+	// the prerelease tags are considered by default, the same as before the option was added
+	assert.Equal(t, "v2.0.0-rc.1", shell.LastReleaseTag(tags))
+	assert.Equal(t, "v1.5.0", shell.LastReleaseTag(tags, shell.WithPrerelease(false)))
+	assert.Equal(t, "v2.0.0-rc.1", shell.LastReleaseTag(tags, shell.WithPrerelease(true)))
+}
+
 func TestGitLevelTopDirCaching(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -92,3 +113,227 @@ func TestGitLevelTopDirCaching(t *testing.T) {
 	assert.Equal(t, path1, path2)
 	assert.Len(t, c.Cache, 1)
 }
+
+func TestRunShellCommandDryRun(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.DryRun = true
+
+	dir := t.TempDir()
+
+	out, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, dir, true, false, "touch", "file")
+	require.NoError(t, err)
+	assert.Empty(t, out.Stdout.String())
+	assert.NoFileExists(t, filepath.Join(dir, "file"))
+
+	out, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, dir, true, false, "git", "--version")
+	require.NoError(t, err)
+	assert.Contains(t, out.Stdout.String(), "git version")
+}
+
+func TestRunShellCommandPathPrepend(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on Windows")
+	}
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"wrapped $PATH\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "wrapped-command"), []byte(script), 0755)) //nolint:gosec
+
+	terragruntOptions.PathPrepend = []string{dir}
+	terragruntOptions.Env = map[string]string{"PATH": "/usr/bin"}
+
+	out, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, false, "wrapped-command")
+	require.NoError(t, err)
+	assert.Equal(t, "wrapped "+dir+":/usr/bin\n", out.Stdout.String())
+	assert.NotContains(t, os.Getenv("PATH"), dir)
+}
+
+func TestGitCommitExists(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repoDir := t.TempDir()
+
+	runGit(t, repoDir, "init", "--quiet")
+	runGit(t, repoDir, "commit", "--quiet", "--allow-empty", "-m", "first")
+	firstCommit := runGit(t, repoDir, "rev-parse", "HEAD")
+	runGit(t, repoDir, "commit", "--quiet", "--allow-empty", "-m", "second")
+	lastCommit := runGit(t, repoDir, "rev-parse", "HEAD")
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	repoURL := &url.URL{Scheme: "file", Path: repoDir}
+
+	testCases := []struct {
+		sha      string
+		expected bool
+	}{
+		{sha: lastCommit, expected: true},
+		{sha: firstCommit, expected: true},
+		{sha: strings.Repeat("0", len(lastCommit)), expected: false},
+	}
+
+	for _, tc := range testCases {
+		exists, err := shell.GitCommitExists(ctx, terragruntOptions, repoURL, tc.sha)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, exists, tc.sha)
+	}
+}
+
+func TestGitTagDate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	tagDate := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	runGit(t, repoDir, "init", "--quiet")
+
+	// the committer date can only be set through the environment
+	cmd := exec.Command("git", "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "release")
+	cmd.Dir = repoDir
+	cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE="+tagDate.Format(time.RFC3339))
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	runGit(t, repoDir, "tag", "v1.0.0")
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	repoURL := &url.URL{Scheme: "file", Path: repoDir}
+
+	date, err := shell.GitTagDate(ctx, terragruntOptions, repoURL, "v1.0.0")
+	require.NoError(t, err)
+	assert.True(t, tagDate.Equal(date), date)
+
+	_, err = shell.GitTagDate(ctx, terragruntOptions, repoURL, "v2.0.0")
+	require.Error(t, err)
+}
+
+func TestGitRepoTagsEnv(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+
+	runGit(t, repoDir, "init", "--quiet")
+	runGit(t, repoDir, "commit", "--quiet", "--allow-empty", "-m", "release")
+	runGit(t, repoDir, "tag", "v1.0.0")
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	// the url is only resolved to the local repository by the git config set in the environment of the options
+	terragruntOptions.Env = map[string]string{
+		"PATH":               os.Getenv("PATH"),
+		"GIT_CONFIG_COUNT":   "1",
+		"GIT_CONFIG_KEY_0":   "url.file://" + filepath.ToSlash(repoDir) + ".insteadOf",
+		"GIT_CONFIG_VALUE_0": "https://git.example.com/org/modules",
+	}
+
+	tags, err := shell.GitRepoTags(context.Background(), terragruntOptions, &url.URL{Scheme: "https", Host: "git.example.com", Path: "/org/modules"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"refs/tags/v1.0.0"}, tags)
+}
+
+func TestLastReleaseTagPrefix(t *testing.T) {
+	t.Parallel()
+	var tags = []string{
+		"refs/tags/v3.0.0",
+		"refs/tags/vpc/v1.4.0",
+		"refs/tags/vpc/v1.10.0",
+		"refs/tags/eks/v2.0.1",
+		"refs/tags/vpc/v2.0.0-rc.1",
+	}
+	assert.Equal(t, "v3.0.0", shell.LastReleaseTag(tags))
+	assert.Equal(t, "vpc/v1.10.0", shell.LastReleaseTag(tags, shell.WithTagPrefix("vpc/"), shell.WithPrerelease(false)))
+	assert.Equal(t, "vpc/v2.0.0-rc.1", shell.LastReleaseTag(tags, shell.WithTagPrefix("vpc/")))
+	assert.Equal(t, "eks/v2.0.1", shell.LastReleaseTag(tags, shell.WithTagPrefix("eks/")))
+	assert.Empty(t, shell.LastReleaseTag(tags, shell.WithTagPrefix("rds/")))
+}
+
+func TestGitLastReleaseTagCaching(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repoDir := t.TempDir()
+
+	runGit(t, repoDir, "init", "--quiet")
+	runGit(t, repoDir, "commit", "--quiet", "--allow-empty", "-m", "init")
+	runGit(t, repoDir, "tag", "v1.0.0")
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	repoURL := &url.URL{Scheme: "file", Path: repoDir}
+
+	tag, err := shell.GitLastReleaseTag(ctx, terragruntOptions, repoURL)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", tag)
+
+	runGit(t, repoDir, "tag", "v2.0.0")
+
+	// the tags of the repository are reused from the first lookup
+	tag, err = shell.GitLastReleaseTag(ctx, terragruntOptions, repoURL)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", tag)
+
+	tag, err = shell.GitLastReleaseTag(ctx, terragruntOptions, repoURL, shell.WithoutCache())
+	require.NoError(t, err)
+	assert.Equal(t, "v2.0.0", tag)
+}
+
+// runGit runs the git command with the passed arguments in the passed directory and returns its trimmed output.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return strings.TrimSpace(string(out))
+}
+
+//nolint:paralleltest
+func TestRunShellCommandObserver(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	var codes []int
+
+	util.SetCommandObserver(util.CommandObserverFunc(func(cmd []string, cmdDir string, _ time.Duration, code int, _ error) {
+		if cmdDir == dir {
+			assert.Equal(t, "sh", cmd[0])
+
+			codes = append(codes, code)
+		}
+	}))
+	t.Cleanup(func() {
+		util.SetCommandObserver(nil)
+	})
+
+	_, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, dir, true, false, "sh", "-c", "exit 0")
+	require.NoError(t, err)
+
+	_, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, dir, true, false, "sh", "-c", "exit 2")
+	require.Error(t, err)
+
+	assert.Equal(t, []int{0, 2}, codes)
+}
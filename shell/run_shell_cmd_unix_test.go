@@ -7,12 +7,14 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/os/signal"
 	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/util"
 
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/stretchr/testify/assert"
@@ -42,6 +44,38 @@ func TestRunShellCommandWithOutputInterrupt(t *testing.T) {
 	})
 
 	actualErr := <-errCh
-	expectedErr := fmt.Sprintf("Failed to execute \"%s 5\" in .\n\nexit status %d", cmdPath, expectedWait)
+	expectedErr := fmt.Sprintf("Execution of \"%s 5\" in . was interrupted by the interrupt signal\n\ncontext canceled\nexit status %d", cmdPath, expectedWait)
 	assert.EqualError(t, actualErr, expectedErr)
+
+	// the command is reported as canceled, regardless of its own exit code
+	exitCode, err := util.GetExitCode(actualErr)
+	require.NoError(t, err)
+	assert.Equal(t, util.CanceledCommandExitCode, exitCode)
+}
+
+func TestRunShellCommandWithOutputArgListTooLong(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	// a single argument over the per-argument limit of Linux, and the total over the limit of macOS
+	args := []string{strings.Repeat("a", 256*1024)}
+	for range 64 {
+		args = append(args, "-var-file="+strings.Repeat("b", 64*1024))
+	}
+
+	_, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, false, "echo", args...)
+	require.Error(t, err)
+
+	var argListErr util.ArgListTooLongError
+	require.ErrorAs(t, err, &argListErr)
+	assert.ErrorIs(t, err, syscall.E2BIG)
+	assert.Greater(t, argListErr.ArgsLength, 4*1024*1024)
+	assert.Contains(t, err.Error(), "the 65 arguments and the environment")
+	assert.NotContains(t, err.Error(), strings.Repeat("b", 100))
+
+	// still matched as the process execution error
+	var processErr util.ProcessExecutionError
+	require.ErrorAs(t, err, &processErr)
 }
@@ -40,3 +40,13 @@ type RegistryAPIErr struct {
 func (err RegistryAPIErr) Error() string {
 	return fmt.Sprintf("Failed to fetch url %s: status code %d", err.url, err.statusCode)
 }
+
+// ModuleMetadataErr is returned if Terragrunt failed to parse the module metadata returned by the registry.
+type ModuleMetadataErr struct {
+	url    string
+	reason string
+}
+
+func (err ModuleMetadataErr) Error() string {
+	return fmt.Sprintf("Error reading module metadata from %s: %s", err.url, err.reason)
+}
@@ -35,6 +35,22 @@ const (
 	defaultRegistryEnvName  = "TG_TF_DEFAULT_REGISTRY_HOST"
 )
 
+// RegistryModuleMetadata is the part of the metadata of a module version returned by the registry, e.g. by
+// https://registry.terraform.io/v1/modules/terraform-aws-modules/vpc/aws/5.0.0
+type RegistryModuleMetadata struct {
+	Version string `json:"version"`
+	// Deprecation is set by the registries which publish the deprecation notices of the modules.
+	Deprecation *RegistryModuleDeprecation `json:"deprecation"`
+}
+
+// RegistryModuleDeprecation is the notice of a deprecated or moved module.
+type RegistryModuleDeprecation struct {
+	Reason string `json:"reason"`
+	// Replacement is the source of the module to use instead, e.g. the one the module moved to.
+	Replacement string `json:"replacement"`
+	Link        string `json:"link"`
+}
+
 // RegistryServicePath is a struct for extracting the modules service path in the Registry.
 type RegistryServicePath struct {
 	ModulesPath string `json:"modules.v1"`
@@ -89,7 +105,12 @@ func (tfrGetter *RegistryGetter) Context() context.Context {
 
 // registryDomain returns the default registry domain to use for the getter.
 func (tfrGetter *RegistryGetter) registryDomain() string {
-	if tfrGetter.TerragruntOptions == nil {
+	return DefaultRegistryDomain(tfrGetter.TerragruntOptions)
+}
+
+// DefaultRegistryDomain returns the registry domain used for the `tfr://` URLs without a domain, e.g. `tfr:///org/name/aws`.
+func DefaultRegistryDomain(opts *options.TerragruntOptions) string {
+	if opts == nil {
 		return defaultRegistryDomain
 	}
 
@@ -98,7 +119,7 @@ func (tfrGetter *RegistryGetter) registryDomain() string {
 		return defaultRegistry
 	}
 	// if binary is set to use OpenTofu registry, use OpenTofu as default registry
-	if tfrGetter.TerragruntOptions.TerraformImplementation == options.OpenTofuImpl {
+	if opts.TerraformImplementation == options.OpenTofuImpl {
 		return defaultOtRegistryDomain
 	}
 
@@ -305,6 +326,24 @@ func GetTerraformGetHeader(ctx context.Context, logger log.Logger, url url.URL)
 	return terraformGet, nil
 }
 
+// GetModuleRegistryMetadata makes an http GET call to the given registry URL of the module version and returns
+// the metadata of the version.
+func GetModuleRegistryMetadata(ctx context.Context, logger log.Logger, metadataURL url.URL) (*RegistryModuleMetadata, error) {
+	body, _, err := httpGETAndGetResponse(ctx, logger, metadataURL)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &RegistryModuleMetadata{}
+	if err := json.Unmarshal(body, metadata); err != nil {
+		reason := fmt.Sprintf("Error parsing response body %s: %s", string(body), err)
+
+		return nil, errors.New(ModuleMetadataErr{url: metadataURL.String(), reason: reason})
+	}
+
+	return metadata, nil
+}
+
 // GetDownloadURLFromHeader checks if the content of the X-Terraform-GET header contains the base url
 // and prepends it if not
 func GetDownloadURLFromHeader(moduleURL url.URL, terraformGet string) (string, error) {
@@ -369,6 +408,25 @@ func BuildRequestURL(registryDomain string, moduleRegistryBasePath string, modul
 
 	moduleFullPath := fmt.Sprintf("%s/%s/%s/download", moduleRegistryBasePath, modulePath, version)
 
+	return buildRegistryURL(registryDomain, moduleFullPath)
+}
+
+// BuildMetadataRequestURL - create url to get the metadata of the module version using moduleRegistryBasePath,
+// the metadata of the latest version is returned by the registry if the version is empty.
+func BuildMetadataRequestURL(registryDomain string, moduleRegistryBasePath string, modulePath string, version string) (*url.URL, error) {
+	moduleRegistryBasePath = strings.TrimSuffix(moduleRegistryBasePath, "/")
+	modulePath = strings.TrimSuffix(modulePath, "/")
+	modulePath = strings.TrimPrefix(modulePath, "/")
+
+	moduleFullPath := fmt.Sprintf("%s/%s", moduleRegistryBasePath, modulePath)
+	if version != "" {
+		moduleFullPath += "/" + version
+	}
+
+	return buildRegistryURL(registryDomain, moduleFullPath)
+}
+
+func buildRegistryURL(registryDomain string, moduleFullPath string) (*url.URL, error) {
 	moduleURL, err := url.Parse(moduleFullPath)
 	if err != nil {
 		return nil, err
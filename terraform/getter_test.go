@@ -2,6 +2,8 @@ package terraform_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -160,3 +162,45 @@ func TestBuildRequestUrlRelativePath(t *testing.T) {
 	assert.Equal(t, "https://gruntwork.io/registry/modules/v1/tfr-project/terraform-aws-tfr/6.6.6/download", requestURL.String())
 
 }
+
+func TestBuildMetadataRequestUrl(t *testing.T) {
+	t.Parallel()
+	requestURL, err := terraform.BuildMetadataRequestURL("gruntwork.io", "/registry/modules/v1/", "/tfr-project/terraform-aws-tfr", "6.6.6")
+	require.NoError(t, err)
+	assert.Equal(t, "https://gruntwork.io/registry/modules/v1/tfr-project/terraform-aws-tfr/6.6.6", requestURL.String())
+
+	requestURL, err = terraform.BuildMetadataRequestURL("gruntwork.io", "/registry/modules/v1/", "/tfr-project/terraform-aws-tfr", "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://gruntwork.io/registry/modules/v1/tfr-project/terraform-aws-tfr", requestURL.String())
+}
+
+func TestGetModuleRegistryMetadata(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/modules/org/vpc/aws/1.0.0" {
+			_, _ = w.Write([]byte(`{"version":"1.0.0","deprecation":{"reason":"Not maintained","replacement":"org/network/aws"}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"version":"2.0.0"}`))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	serverURL.Path = "/v1/modules/org/vpc/aws/1.0.0"
+
+	metadata, err := terraform.GetModuleRegistryMetadata(context.Background(), log.New(), *serverURL)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", metadata.Version)
+	assert.Equal(t, &terraform.RegistryModuleDeprecation{Reason: "Not maintained", Replacement: "org/network/aws"}, metadata.Deprecation)
+
+	serverURL.Path = "/v1/modules/org/vpc/aws"
+
+	metadata, err = terraform.GetModuleRegistryMetadata(context.Background(), log.New(), *serverURL)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", metadata.Version)
+	assert.Nil(t, metadata.Deprecation)
+}
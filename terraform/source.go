@@ -197,7 +197,7 @@ func NewSource(source string, downloadDir string, workingDir string, logger log.
 // This method should be able to handle all source URLs that the terraform
 // init command can handle, parsing local file paths, Git paths, and HTTP URLs correctly.
 func ToSourceURL(source string, workingDir string) (*url.URL, error) {
-	source, err := normalizeSourceURL(source, workingDir)
+	source, err := normalizeSourceURLForScheme(source, workingDir)
 	if err != nil {
 		return nil, err
 	}
@@ -212,8 +212,39 @@ func ToSourceURL(source string, workingDir string) (*url.URL, error) {
 	return parseSourceURL(rawSourceURLWithGetter)
 }
 
-// We have to remove the http(s) scheme from the source URL to allow `getter.Detect` to add the source type, but only if the `getter` has a detector for that host.
-func normalizeSourceURL(source string, workingDir string) (string, error) {
+// NormalizeSourceURL returns a key which is the same for source URLs pointing to the same repo and module path, regardless
+// of the scheme, the forced getter, the user, the port, the `.git` suffix, the case of the host and the query string,
+// including the ref. For example, all of the following sources are converted to github.com/org/repo//modules/vpc:
+//
+//	git@github.com:org/repo.git//modules/vpc
+//	https://github.com/org/repo.git//modules/vpc
+//	git::ssh://git@GitHub.com/org/repo.git//modules/vpc?ref=v1.0.0
+//
+// Local sources are converted to their path.
+func NormalizeSourceURL(source string, workingDir string) (string, error) {
+	sourceURL, err := ToSourceURL(source, workingDir)
+	if err != nil {
+		return "", err
+	}
+
+	if IsLocalSource(sourceURL) {
+		return filepath.ToSlash(filepath.Clean(sourceURL.Path)), nil
+	}
+
+	repoPath, modulePath, hasModulePath := strings.Cut(sourceURL.Path, "//")
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+
+	key := strings.ToLower(sourceURL.Hostname()) + "/" + repoPath
+
+	if modulePath = strings.Trim(modulePath, "/"); hasModulePath && modulePath != "" {
+		key += "//" + modulePath
+	}
+
+	return key, nil
+}
+
+// normalizeSourceURLForScheme removes the http(s) scheme from the source URL to allow `getter.Detect` to add the source type, but only if the `getter` has a detector for that host.
+func normalizeSourceURLForScheme(source string, workingDir string) (string, error) {
 	newSource := httpSchemeRegexp.ReplaceAllString(source, "")
 
 	// We can't use `the getter.Detectors` global variable because we need to exclude from checking:
@@ -96,6 +96,37 @@ func TestToSourceUrl(t *testing.T) {
 	}
 }
 
+func TestNormalizeSourceURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		sourceURL   string
+		expectedKey string
+	}{
+		{"git@github.com:gruntwork-io/repo-name.git", "github.com/gruntwork-io/repo-name"},
+		{"https://github.com/gruntwork-io/repo-name.git", "github.com/gruntwork-io/repo-name"},
+		{"https://github.com/gruntwork-io/repo-name", "github.com/gruntwork-io/repo-name"},
+		{"git::ssh://git@GitHub.com/gruntwork-io/repo-name.git?ref=v0.53.8", "github.com/gruntwork-io/repo-name"},
+		{"git::ssh://git@github.com:2222/gruntwork-io/repo-name.git", "github.com/gruntwork-io/repo-name"},
+		{"git@github.com:gruntwork-io/repo-name.git//modules/module-name?ref=v0.53.8", "github.com/gruntwork-io/repo-name//modules/module-name"},
+		{"git::https://github.com/gruntwork-io/repo-name.git//modules/module-name/", "github.com/gruntwork-io/repo-name//modules/module-name"},
+		{"https://s3-eu-west-1.amazonaws.com/modules/vpc.zip", "s3-eu-west-1.amazonaws.com/modules/vpc.zip"},
+		{"/tmp/modules/vpc/", "/tmp/modules/vpc"},
+	}
+
+	for i, testCase := range testCases {
+		// Save a local copy in scope so all the tests don't run the final item in the loop
+		testCase := testCase
+		t.Run(fmt.Sprintf("testCase-%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			actualKey, err := terraform.NormalizeSourceURL(testCase.sourceURL, os.TempDir())
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedKey, actualKey)
+		})
+	}
+}
+
 func TestRegressionSupportForGitRemoteCodecommit(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,33 @@
+variable "environment" {
+  type        = string
+  description = "Environment name"
+}
+
+variable "name_prefix" {
+  type        = string
+  description = "Prefix of the resource names"
+  default     = var.environment
+}
+
+variable "bucket_name" {
+  type    = string
+  default = "${var.environment}-${local.suffix}"
+}
+
+variable "tags" {
+  type = map(string)
+  default = merge(
+    { Environment = var.environment },
+    { Team = "platform" },
+  )
+}
+
+variable "zones" {
+  type    = list(string)
+  default = [upper("a"), "b"]
+}
+
+variable "port" {
+  type    = number
+  default = 8080
+}
@@ -0,0 +1,14 @@
+output "vpc_id" {
+  description = "ID of the VPC"
+  value       = "vpc-123"
+}
+
+output "subnet_ids" {
+  value = ["subnet-1", "subnet-2"]
+}
+
+output "db_password" {
+  description = "Password of the database"
+  value       = "secret"
+  sensitive   = true
+}
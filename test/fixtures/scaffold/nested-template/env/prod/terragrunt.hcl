@@ -0,0 +1,14 @@
+# env/prod unit generated from the nested template
+terraform {
+  source = "{{ .sourceUrl }}"
+}
+
+inputs = {
+  environment = "prod"
+{{- range .requiredVariables }}
+  {{ .Name }} = {{ .DefaultValuePlaceholder }}  # TODO: fill in value
+{{- end }}
+{{- range .optionalVariables }}
+  {{ .Name }} = {{ .DefaultValue }}
+{{- end }}
+}
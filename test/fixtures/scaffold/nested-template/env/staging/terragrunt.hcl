@@ -0,0 +1,14 @@
+# env/staging unit generated from the nested template
+terraform {
+  source = "{{ .sourceUrl }}"
+}
+
+inputs = {
+  environment = "staging"
+{{- range .requiredVariables }}
+  {{ .Name }} = {{ .DefaultValuePlaceholder }}  # TODO: fill in value
+{{- end }}
+{{- range .optionalVariables }}
+  {{ .Name }} = {{ .DefaultValue }}
+{{- end }}
+}
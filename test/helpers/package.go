@@ -644,8 +644,8 @@ func WrappedBinary() string {
 	value, found := os.LookupEnv("TERRAGRUNT_TFPATH")
 	if !found {
 		// if env variable is not defined, try to check through executing command
-		if util.IsCommandExecutable(TofuBinary, "-version") {
-			return TofuBinary
+		if binary, ok := util.FirstExecutable(context.Background(), [][]string{{TofuBinary, "-version"}, {TerraformBinary, "-version"}}); ok {
+			return binary
 		}
 
 		return TerraformBinary
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/test/helpers"
 	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +22,7 @@ const (
 	testScaffoldTemplateModule         = "git@github.com:gruntwork-io/terragrunt.git//test/fixtures/scaffold/module-with-template"
 	testScaffoldExternalTemplateModule = "git@github.com:gruntwork-io/terragrunt.git//test/fixtures/scaffold/external-template"
 	testScaffoldLocalModulePath        = "fixtures/scaffold/scaffold-module"
+	testScaffoldNestedTemplatePath     = "fixtures/scaffold/nested-template"
 	testScaffold3rdPartyModulePath     = "git::https://github.com/Azure/terraform-azurerm-avm-res-compute-virtualmachine.git//.?ref=v0.15.0"
 )
 
@@ -178,6 +181,69 @@ func TestScaffoldLocalModule(t *testing.T) {
 	assert.FileExists(t, tmpEnvPath+"/terragrunt.hcl")
 }
 
+func TestScaffoldLocalModuleNestedTemplate(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath, err := os.MkdirTemp("", "terragrunt-scaffold-test")
+	require.NoError(t, err)
+
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	moduleURL := fmt.Sprintf("%s//%s", workingDir, testScaffoldLocalModulePath)
+	templateURL := fmt.Sprintf("%s//%s", workingDir, testScaffoldNestedTemplatePath)
+
+	_, stderr, err := helpers.RunTerragruntCommandWithOutput(t, fmt.Sprintf("terragrunt --terragrunt-non-interactive --terragrunt-working-dir %s scaffold %s %s", tmpEnvPath, moduleURL, templateURL))
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "Scaffolding completed")
+
+	for _, unit := range []string{"env/prod", "env/prod/us-east-1", "env/staging"} {
+		content, err := os.ReadFile(filepath.Join(tmpEnvPath, unit, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		// every level of the template receives the module variables
+		assert.Contains(t, string(content), "project_name")
+		assert.Contains(t, string(content), "replica_count")
+		// and the generated files are formatted
+		assert.Equal(t, string(hclwrite.Format(content)), string(content))
+	}
+}
+
+func TestScaffoldLocalModuleKeepTemp(t *testing.T) {
+	t.Parallel()
+
+	tmpEnvPath, err := os.MkdirTemp("", "terragrunt-scaffold-test")
+	require.NoError(t, err)
+
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+
+	moduleURL := fmt.Sprintf("%s//%s", workingDir, testScaffoldLocalModulePath)
+
+	_, stderr, err := helpers.RunTerragruntCommandWithOutput(t, fmt.Sprintf("terragrunt --terragrunt-non-interactive --terragrunt-working-dir %s scaffold --terragrunt-scaffold-keep-temp %s", tmpEnvPath, moduleURL))
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "Scaffolding completed")
+
+	matches := regexp.MustCompile(`Keeping temporary directory (\S+)`).FindAllStringSubmatch(stderr, -1)
+	require.Len(t, matches, 2)
+
+	var keptDirs []string
+
+	for _, match := range matches {
+		// the logged paths are relative to the working directory
+		keptDir := filepath.Join(tmpEnvPath, match[1])
+		keptDirs = append(keptDirs, keptDir)
+
+		t.Cleanup(func() {
+			os.RemoveAll(keptDir)
+		})
+	}
+
+	// the downloaded module is kept along with the default boilerplate files
+	assert.FileExists(t, filepath.Join(keptDirs[0], "main.tf"))
+	assert.FileExists(t, filepath.Join(keptDirs[1], "boilerplate.yml"))
+}
+
 func TestScaffold3rdPartyModule(t *testing.T) {
 	t.Parallel()
 
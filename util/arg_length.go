@@ -0,0 +1,64 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"syscall"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// argPointerSize is the size of the pointer to each argument and environment variable, which the OS counts
+// towards the argument length limit along with the strings themselves.
+const argPointerSize = strconv.IntSize / 8
+
+// ArgsByteLength estimates the number of bytes the command with the given arguments and environment takes of
+// the OS argument length limit, `ARG_MAX` on Unix, so callers can split or consolidate the arguments before running
+// the command, e.g. by merging many `-var-file` into one. Each string is counted with its null terminator and pointer.
+func ArgsByteLength(command string, args []string, env map[string]string) int {
+	length := len(command) + 1 + argPointerSize
+
+	for _, arg := range args {
+		length += len(arg) + 1 + argPointerSize
+	}
+
+	for name, value := range env {
+		length += len(name) + len("=") + len(value) + 1 + argPointerSize
+	}
+
+	return length
+}
+
+// ArgListTooLongError is the `ProcessExecutionError` of the command that could not be started, since its arguments
+// and environment exceed the OS argument length limit, which the OS reports with the cryptic E2BIG errno.
+type ArgListTooLongError struct {
+	ProcessExecutionError
+	// ArgsLength is the estimated length of the arguments and the environment, see `ArgsByteLength`.
+	ArgsLength int
+}
+
+// NewArgListTooLongError returns `ArgListTooLongError` if the given error is the `ProcessExecutionError` caused by
+// E2BIG, the error is returned as is otherwise.
+func NewArgListTooLongError(err error, env map[string]string) error {
+	var processErr ProcessExecutionError
+	if !errors.As(err, &processErr) || !errors.Is(processErr.Err, syscall.E2BIG) {
+		return err
+	}
+
+	return errors.New(ArgListTooLongError{
+		ProcessExecutionError: processErr,
+		ArgsLength:            ArgsByteLength(processErr.Command, processErr.Args, env),
+	})
+}
+
+// Error does not print the arguments, since there are too many of them to be readable.
+func (err ArgListTooLongError) Error() string {
+	return fmt.Sprintf("Failed to execute %q in %s: the %d arguments and the environment, about %d bytes, exceed the argument length limit of the OS.\n"+
+		"Consolidate the -var-file and -var arguments into fewer var files, or reduce the number of -target arguments.\n%v",
+		err.Command, err.WorkingDir, len(err.Args), err.ArgsLength, err.Err)
+}
+
+// Unwrap returns the `ProcessExecutionError`, so the error can still be matched by `errors.As`.
+func (err ArgListTooLongError) Unwrap() error {
+	return err.ProcessExecutionError
+}
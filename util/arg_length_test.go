@@ -0,0 +1,30 @@
+package util_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgsByteLength(t *testing.T) {
+	t.Parallel()
+
+	pointerSize := strconv.IntSize / 8
+
+	assert.Equal(t, len("terraform")+1+pointerSize, util.ArgsByteLength("terraform", nil, nil))
+	assert.Equal(t,
+		len("terraform")+len("plan")+len("-target=a")+len("TF_LOG=debug")+4*(1+pointerSize),
+		util.ArgsByteLength("terraform", []string{"plan", "-target=a"}, map[string]string{"TF_LOG": "debug"}))
+}
+
+func TestNewArgListTooLongError(t *testing.T) {
+	t.Parallel()
+
+	// the other errors are returned as is
+	err := errors.New(util.ProcessExecutionError{Err: errors.New("exit status 1"), Command: "terraform"})
+	assert.Same(t, err, util.NewArgListTooLongError(err, nil))
+	assert.NoError(t, util.NewArgListTooLongError(nil, nil))
+}
@@ -0,0 +1,69 @@
+package util
+
+import (
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// CommandObserver is notified about every command executed by Terragrunt once it completes, e.g. to collect
+// the durations of the terraform invocations of `run-all`. It's called concurrently by the commands run in parallel.
+type CommandObserver interface {
+	// OnComplete is called with the command followed by its arguments, the directory it ran in, its wall-clock
+	// duration and exit code, and the error it failed with. The exit code is -1 if the command failed to start
+	// or was terminated by a signal. The arguments are passed as is, use `RedactArgs` before reporting them.
+	OnComplete(cmd []string, dir string, dur time.Duration, code int, err error)
+}
+
+// CommandObserverFunc is an adapter to use an ordinary function as `CommandObserver`.
+type CommandObserverFunc func(cmd []string, dir string, dur time.Duration, code int, err error)
+
+// OnComplete implements `CommandObserver` interface.
+func (fn CommandObserverFunc) OnComplete(cmd []string, dir string, dur time.Duration, code int, err error) {
+	fn(cmd, dir, dur, code, err)
+}
+
+var (
+	commandObserverMu sync.RWMutex    //nolint:gochecknoglobals
+	commandObserver   CommandObserver //nolint:gochecknoglobals
+)
+
+// SetCommandObserver sets the observer notified about the executed commands, nil removes it.
+func SetCommandObserver(observer CommandObserver) {
+	commandObserverMu.Lock()
+	defer commandObserverMu.Unlock()
+
+	commandObserver = observer
+}
+
+// ObserveCommand notifies the observer set with `SetCommandObserver`, if any, that the command started at the given
+// time has completed with the given error. The exit code is taken from the error.
+func ObserveCommand(startTime time.Time, dir string, command string, args []string, err error) {
+	commandObserverMu.RLock()
+	observer := commandObserver
+	commandObserverMu.RUnlock()
+
+	if observer == nil {
+		return
+	}
+
+	cmd := append([]string{command}, args...)
+
+	observer.OnComplete(cmd, dir, time.Since(startTime), commandExitCode(err), err)
+}
+
+// commandExitCode returns the exit code of the command that completed with the given error.
+func commandExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+
+	return -1
+}
@@ -0,0 +1,61 @@
+package util_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type observedCommand struct {
+	err  error
+	cmd  []string
+	dir  string
+	dur  time.Duration
+	code int
+}
+
+//nolint:paralleltest
+func TestCommandObserver(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+
+	dir := t.TempDir()
+
+	var observed []observedCommand
+
+	util.SetCommandObserver(util.CommandObserverFunc(func(cmd []string, cmdDir string, dur time.Duration, code int, err error) {
+		if cmdDir == dir {
+			observed = append(observed, observedCommand{cmd: cmd, dir: cmdDir, dur: dur, code: code, err: err})
+		}
+	}))
+	t.Cleanup(func() {
+		util.SetCommandObserver(nil)
+	})
+
+	assert.True(t, util.IsCommandExecutableInDir(context.Background(), dir, "sh", "-c", "exit 0"))
+	assert.False(t, util.IsCommandExecutableInDir(context.Background(), dir, "sh", "-c", "exit 3"))
+	assert.False(t, util.IsCommandExecutableInDir(context.Background(), dir, "not-existing-command"))
+
+	require.Len(t, observed, 3)
+
+	assert.Equal(t, []string{"sh", "-c", "exit 0"}, observed[0].cmd)
+	assert.Equal(t, 0, observed[0].code)
+	require.NoError(t, observed[0].err)
+	assert.Positive(t, observed[0].dur)
+
+	assert.Equal(t, 3, observed[1].code)
+	require.Error(t, observed[1].err)
+
+	assert.Equal(t, -1, observed[2].code)
+	require.Error(t, observed[2].err)
+
+	util.SetCommandObserver(nil)
+	assert.True(t, util.IsCommandExecutableInDir(context.Background(), dir, "sh", "-c", "exit 0"))
+	assert.Len(t, observed, 3)
+}
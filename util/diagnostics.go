@@ -0,0 +1,102 @@
+package util
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TerraformDiagnostic is a diagnostic, either an error or a warning, printed by terraform/tofu run with the `-json` flag.
+type TerraformDiagnostic struct {
+	Range    *TerraformDiagnosticRange `json:"range,omitempty"`
+	Severity string                    `json:"severity"`
+	Summary  string                    `json:"summary"`
+	Detail   string                    `json:"detail"`
+}
+
+// TerraformDiagnosticRange is the location of the configuration the diagnostic refers to.
+type TerraformDiagnosticRange struct {
+	Filename string `json:"filename"`
+	Start    struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"start"`
+}
+
+// String returns the diagnostic in the `<Severity>: <summary> (<file>:<line>)` form, followed by the indented detail.
+func (diag TerraformDiagnostic) String() string {
+	severity := diag.Severity
+	if severity != "" {
+		severity = strings.ToUpper(severity[:1]) + severity[1:]
+	}
+
+	str := fmt.Sprintf("%s: %s", severity, diag.Summary)
+
+	if diag.Range != nil && diag.Range.Filename != "" {
+		str += fmt.Sprintf(" (%s:%d)", diag.Range.Filename, diag.Range.Start.Line)
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(diag.Detail), "\n") {
+		if line != "" {
+			str += "\n  " + line
+		}
+	}
+
+	return str
+}
+
+// ParseTerraformDiagnostics parses the diagnostics from the output of terraform/tofu run with the `-json` flag.
+// Both the single object printed by `validate -json` and the stream of JSON messages printed by other commands,
+// one per line, are supported. The lines which are not JSON messages are skipped.
+func ParseTerraformDiagnostics(output string) []TerraformDiagnostic {
+	var validateOutput struct {
+		Diagnostics []TerraformDiagnostic `json:"diagnostics"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &validateOutput); err == nil && len(validateOutput.Diagnostics) > 0 {
+		return validateOutput.Diagnostics
+	}
+
+	var diagnostics []TerraformDiagnostic
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(nil, len(output)+1)
+
+	for scanner.Scan() {
+		var message struct {
+			Diagnostic *TerraformDiagnostic `json:"diagnostic"`
+			Type       string               `json:"type"`
+		}
+
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil || message.Type != "diagnostic" || message.Diagnostic == nil {
+			continue
+		}
+
+		diagnostics = append(diagnostics, *message.Diagnostic)
+	}
+
+	return diagnostics
+}
+
+// TerraformDiagnosticsError is the `ProcessExecutionError` of terraform/tofu run with the `-json` flag, whose output
+// is summarized by the parsed diagnostics instead of the raw JSON messages.
+type TerraformDiagnosticsError struct {
+	ProcessExecutionError
+	Diagnostics []TerraformDiagnostic
+}
+
+func (err TerraformDiagnosticsError) Error() string {
+	summaries := make([]string, len(err.Diagnostics))
+
+	for i, diag := range err.Diagnostics {
+		summaries[i] = diag.String()
+	}
+
+	return err.message(strings.Join(summaries, "\n"))
+}
+
+// Unwrap returns the `ProcessExecutionError`, so the error can still be matched by `errors.As`.
+func (err TerraformDiagnosticsError) Unwrap() error {
+	return err.ProcessExecutionError
+}
@@ -0,0 +1,63 @@
+package util_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTerraformDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	streamOutput := `{"@level":"info","@message":"Terraform 1.9.0","type":"version"}
+not a json message
+{"@level":"error","@message":"Error: Unsupported argument","type":"diagnostic","diagnostic":{"severity":"error","summary":"Unsupported argument","detail":"An argument named \"foo\" is not expected here.","range":{"filename":"main.tf","start":{"line":3,"column":3}}}}
+{"@level":"warn","@message":"Warning: Deprecated","type":"diagnostic","diagnostic":{"severity":"warning","summary":"Deprecated","detail":""}}
+`
+
+	diagnostics := util.ParseTerraformDiagnostics(streamOutput)
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, "Error: Unsupported argument (main.tf:3)\n  An argument named \"foo\" is not expected here.", diagnostics[0].String())
+	assert.Equal(t, "Warning: Deprecated", diagnostics[1].String())
+
+	validateOutput := `{
+  "valid": false,
+  "error_count": 1,
+  "diagnostics": [
+    {"severity": "error", "summary": "Missing required argument", "detail": "The argument \"name\" is required.", "range": {"filename": "vars.tf", "start": {"line": 7}}}
+  ]
+}`
+
+	diagnostics = util.ParseTerraformDiagnostics(validateOutput)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "Error: Missing required argument (vars.tf:7)\n  The argument \"name\" is required.", diagnostics[0].String())
+
+	assert.Empty(t, util.ParseTerraformDiagnostics("Error: Unsupported argument\n\n  on main.tf line 3"))
+}
+
+func TestTerraformDiagnosticsError(t *testing.T) {
+	t.Parallel()
+
+	processErr := util.ProcessExecutionError{
+		Err:        errors.New("exit status 1"),
+		Command:    "tofu",
+		Args:       []string{"plan", "-json"},
+		WorkingDir: "/tmp",
+	}
+	processErr.Output.Stderr.WriteString(`{"type":"diagnostic","diagnostic":{"severity":"error","summary":"Unsupported argument"}}`)
+
+	var err error = util.TerraformDiagnosticsError{
+		ProcessExecutionError: processErr,
+		Diagnostics:           []util.TerraformDiagnostic{{Severity: "error", Summary: "Unsupported argument"}},
+	}
+
+	assert.Equal(t, "Failed to execute \"tofu plan -json\" in /tmp\nError: Unsupported argument\nexit status 1", err.Error())
+
+	var matchedErr util.ProcessExecutionError
+
+	require.ErrorAs(t, err, &matchedErr)
+	assert.Equal(t, []string{"plan", "-json"}, matchedErr.Args)
+}
@@ -26,6 +26,7 @@ const (
 	TerragruntCacheDir    = ".terragrunt-cache"
 	DefaultBoilerplateDir = ".boilerplate"
 	TfFileExtension       = ".tf"
+	TfJSONFileExtension   = ".tf.json"
 	ChecksumReadBlock     = 8192
 )
 
@@ -636,7 +637,21 @@ func (err PathIsNotFile) Error() string {
 	return err.path + " is not a file"
 }
 
-// ListTfFiles returns a list of all TF files in the specified directory.
+// TempDirNotWritableError is returned when files can't be created in the temp directory.
+type TempDirNotWritableError struct {
+	dir string
+	err error
+}
+
+func (err TempDirNotWritableError) Error() string {
+	return fmt.Sprintf("The temp directory %s is not writable: %v. Set the TMPDIR environment variable to a writable directory with enough free space.", err.dir, err.err)
+}
+
+func (err TempDirNotWritableError) Unwrap() error {
+	return err.err
+}
+
+// ListTfFiles returns a list of all TF files, including the JSON ones, in the specified directory.
 func ListTfFiles(directoryPath string) ([]string, error) {
 	var tfFiles []string
 
@@ -645,7 +660,7 @@ func ListTfFiles(directoryPath string) ([]string, error) {
 			return err
 		}
 
-		if !info.IsDir() && filepath.Ext(path) == TfFileExtension {
+		if !info.IsDir() && (filepath.Ext(path) == TfFileExtension || strings.HasSuffix(path, TfJSONFileExtension)) {
 			tfFiles = append(tfFiles, path)
 		}
 
@@ -705,6 +720,32 @@ func GetTempDir() (string, error) {
 	return tempDir, nil
 }
 
+// CheckTempDirWritable verifies that files can be created in the temp directory by writing and removing a probe file,
+// so that a read-only or full temp directory is reported before any work is done, e.g. before downloading sources.
+func CheckTempDirWritable() error {
+	tempDir := os.TempDir()
+
+	probe, err := os.CreateTemp(tempDir, "terragrunt-probe-*")
+	if err != nil {
+		return errors.New(TempDirNotWritableError{dir: tempDir, err: err})
+	}
+
+	_, writeErr := probe.WriteString("probe")
+	closeErr := probe.Close()
+
+	if err := os.Remove(probe.Name()); err != nil {
+		return errors.New(TempDirNotWritableError{dir: tempDir, err: err})
+	}
+
+	for _, err := range []error{writeErr, closeErr} {
+		if err != nil {
+			return errors.New(TempDirNotWritableError{dir: tempDir, err: err})
+		}
+	}
+
+	return nil
+}
+
 // GetExcludeDirsFromFile returns a list of directories from the given filename, where each directory path starts on a new line.
 func GetExcludeDirsFromFile(baseDir, filename string) ([]string, error) {
 	filename, err := CanonicalPath(filename, baseDir)
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strconv"
 	"testing"
@@ -573,3 +574,30 @@ func TestWalkWithSymlinksErrors(t *testing.T) {
 		return err
 	}))
 }
+
+//nolint:paralleltest
+func TestCheckTempDirWritable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The temp directory is not read from TMPDIR on Windows")
+	}
+
+	tempDir := t.TempDir()
+
+	t.Setenv("TMPDIR", tempDir)
+	require.NoError(t, util.CheckTempDirWritable())
+
+	entries, err := os.ReadDir(tempDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	missingDir := filepath.Join(tempDir, "missing")
+	t.Setenv("TMPDIR", missingDir)
+
+	err = util.CheckTempDirWritable()
+
+	var tempDirErr util.TempDirNotWritableError
+
+	require.ErrorAs(t, err, &tempDirErr)
+	assert.Contains(t, err.Error(), missingDir)
+	assert.Contains(t, err.Error(), "TMPDIR")
+}
@@ -2,44 +2,466 @@ package util
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"os/exec"
 
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/redact"
 	"github.com/gruntwork-io/terragrunt/pkg/cli"
 )
 
 // IsCommandExecutable - returns true if a command can be executed without errors.
-func IsCommandExecutable(command string, args ...string) bool {
-	cmd := exec.Command(command, args...)
+func IsCommandExecutable(ctx context.Context, command string, args ...string) bool {
+	return CommandExecutableStatus(ctx, command, args...).Status == CommandSucceeded
+}
+
+// IsCommandExecutableInDir - returns true if a command can be executed without errors in the given directory,
+// e.g. a tool wrapper script that exists only in a module directory.
+func IsCommandExecutableInDir(ctx context.Context, dir string, command string, args ...string) bool {
+	return commandExecutableStatus(ctx, dir, nil, command, args...).Status == CommandSucceeded
+}
+
+// IsCommandExecutableWithPath - returns true if a command can be executed without errors with the given directories
+// prepended to PATH, the same way as the commands run with `TerragruntOptions.PathPrepend`.
+func IsCommandExecutableWithPath(ctx context.Context, pathPrepend []string, command string, args ...string) bool {
+	return commandExecutableStatus(ctx, "", pathPrepend, command, args...).Status == CommandSucceeded
+}
+
+// PrependPath returns the PATH value with the given directories placed before the directories of pathValue.
+// The empty directories are skipped.
+func PrependPath(pathValue string, dirs ...string) string {
+	var list []string
+
+	for _, dir := range dirs {
+		if dir != "" {
+			list = append(list, dir)
+		}
+	}
+
+	if pathValue != "" {
+		list = append(list, pathValue)
+	}
+
+	return strings.Join(list, string(os.PathListSeparator))
+}
+
+// EnvWithPathPrepend returns a copy of env with the given directories prepended to its PATH. If env has no PATH,
+// the PATH of the current process is merged instead. The env is returned as is if there are no directories.
+func EnvWithPathPrepend(env map[string]string, dirs []string) map[string]string {
+	if len(dirs) == 0 {
+		return env
+	}
+
+	pathValue, ok := env[pathEnvName]
+	if !ok {
+		pathValue = os.Getenv(pathEnvName)
+	}
+
+	env = CloneStringMap(env)
+	env[pathEnvName] = PrependPath(pathValue, dirs...)
+
+	return env
+}
+
+// LookPathIn returns the path to the command found in one of the given directories. The command is returned as is
+// if it contains a path separator or is not found in them, so it's resolved using the inherited PATH. Unlike changing
+// the PATH of the current process, this keeps the lookup per command.
+func LookPathIn(dirs []string, command string) string {
+	if strings.ContainsRune(command, filepath.Separator) || strings.ContainsRune(command, '/') {
+		return command
+	}
+
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+
+		// a path with a separator is checked directly, on Windows with the PATHEXT extensions
+		if path, err := exec.LookPath(filepath.Join(dir, command)); err == nil {
+			return path
+		}
+	}
+
+	return command
+}
+
+// FirstExecutable returns the first of the given candidates, each one a command followed by its arguments,
+// that can be executed without errors, e.g. `FirstExecutable([][]string{{"tofu", "-version"}, {"terraform", "-version"}})`.
+// Returns false if none of the candidates can be executed.
+func FirstExecutable(ctx context.Context, candidates [][]string) (string, bool) {
+	for _, candidate := range candidates {
+		if len(candidate) == 0 {
+			continue
+		}
+
+		if IsCommandExecutable(ctx, candidate[0], candidate[1:]...) {
+			return candidate[0], true
+		}
+	}
+
+	return "", false
+}
+
+// readOnlyTerraformCommands are the terraform subcommands which don't change the state, the infrastructure or the files.
+var readOnlyTerraformCommands = []string{"version", "-version", "--version", "-help", "--help", "output", "show", "providers", "graph", "validate"} //nolint:gochecknoglobals
+
+// readOnlyTerraformSubcommands are the subcommands of terraform commands, such as `state list`, which are read-only.
+var readOnlyTerraformSubcommands = map[string][]string{ //nolint:gochecknoglobals
+	"state":     {"list", "show"},
+	"workspace": {"list", "show"},
+}
+
+// readOnlyGitCommands are the git subcommands which only read the repository or the remote.
+var readOnlyGitCommands = []string{"ls-remote", "rev-parse", "log", "show", "status", "describe", "diff", "ls-files", "version", "--version"} //nolint:gochecknoglobals
+
+// IsReadOnlyCommand returns true if the command with the given arguments is known not to change anything, so it's safe
+// to run in the dry-run mode, e.g. `terraform version`, `git ls-remote` or `tofu state list`. Commands which are not known
+// to be read-only, including any other executables, are considered mutating.
+func IsReadOnlyCommand(command string, args ...string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	switch strings.TrimSuffix(filepath.Base(command), ".exe") {
+	case "terraform", "tofu":
+		if subcommands, ok := readOnlyTerraformSubcommands[args[0]]; ok {
+			return len(args) > 1 && ListContainsElement(subcommands, args[1])
+		}
+
+		return ListContainsElement(readOnlyTerraformCommands, args[0])
+	case "git":
+		return ListContainsElement(readOnlyGitCommands, args[0])
+	}
+
+	return false
+}
+
+// CommandStatus is the outcome of running a command by `CommandExecutableStatus`.
+type CommandStatus int
+
+const (
+	// CommandSucceeded means that the command ran and exited with zero code.
+	CommandSucceeded CommandStatus = iota
+	// CommandNotFound means that the command binary is not found on PATH.
+	CommandNotFound
+	// CommandFailed means that the command ran but exited with non-zero code, or could not be started.
+	CommandFailed
+)
+
+// CommandExecutableResult is the result of `CommandExecutableStatus`.
+type CommandExecutableResult struct {
+	// Err is the error returned by running the command, nil if the command succeeded.
+	Err error
+	// Status is the outcome of running the command.
+	Status CommandStatus
+	// ExitCode is the exit code of the failed command, -1 if the command didn't exit by itself.
+	ExitCode int
+}
+
+// CommandExecutableStatus runs the command and reports whether it is missing, failed or succeeded, so callers
+// can tell "please install X" from "X returned an error".
+func CommandExecutableStatus(ctx context.Context, command string, args ...string) CommandExecutableResult {
+	return commandExecutableStatus(ctx, "", nil, command, args...)
+}
+
+// commandExecutableStatus runs the command in the given directory, the current directory is used if dir is empty.
+// The pathPrepend directories are prepended to the PATH of the command only.
+func commandExecutableStatus(ctx context.Context, dir string, pathPrepend []string, command string, args ...string) CommandExecutableResult {
+	cmd := exec.CommandContext(ctx, LookPathIn(pathPrepend, command), args...)
+	cmd.Dir = dir
+
+	if len(pathPrepend) > 0 {
+		pathValue := PrependPath(os.Getenv(pathEnvName), pathPrepend...)
+		cmd.Env = append(os.Environ(), pathEnvName+"="+pathValue)
+	}
+
 	cmd.Stdin = nil
 	cmd.Stdout = nil
 	cmd.Stderr = nil
 
-	if err := cmd.Run(); err != nil {
-		var exitErr *exec.ExitError
-		if ok := errors.As(err, &exitErr); ok {
-			return exitErr.ExitCode() == 0
-		}
+	startTime := time.Now()
+	err := WithContextError(ctx, cmd.Run())
 
-		return false
+	ObserveCommand(startTime, dir, command, args, err)
+
+	switch kind, _ := ClassifyCommandError(err); kind {
+	case CommandKindSuccess:
+		return CommandExecutableResult{Status: CommandSucceeded}
+	case CommandKindNotFound:
+		return CommandExecutableResult{Status: CommandNotFound, ExitCode: -1, Err: err}
+	}
+
+	result := CommandExecutableResult{Status: CommandFailed, ExitCode: -1, Err: err}
+
+	var exitErr *exec.ExitError
+	if ok := errors.As(err, &exitErr); ok {
+		result.ExitCode = exitErr.ExitCode()
 	}
 
-	return true
+	return result
 }
 
 type CmdOutput struct {
 	Stdout bytes.Buffer
 	Stderr bytes.Buffer
+	// Truncated is true if the output exceeded the max output size and was truncated.
+	Truncated bool
+}
+
+// pathEnvName is the name of the environment variable listing the directories searched for executables.
+const pathEnvName = "PATH"
+
+// alwaysAllowedEnvVars are passed to commands run by `RunCommandWithAllowedEnv` regardless of the allowlist,
+// since they are required by most of the commands and don't hold secrets.
+var alwaysAllowedEnvVars = []string{"PATH", "HOME", "USER", "LANG", "TMPDIR", "TEMP", "TMP", "SYSTEMROOT"} //nolint:gochecknoglobals
+
+// RunCommandWithAllowedEnv runs the command in the given working directory with only the allowlisted environment
+// variables taken from env, instead of inheriting the whole environment, so secrets are not leaked to the subprocess.
+func RunCommandWithAllowedEnv(ctx context.Context, workingDir string, env map[string]string, allowedEnv []string, command string, args ...string) (*CmdOutput, error) {
+	var output CmdOutput
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = workingDir
+	cmd.Stdout = &output.Stdout
+	cmd.Stderr = &output.Stderr
+	// an empty non-nil slice keeps exec from falling back to the environment of the current process
+	cmd.Env = []string{}
+
+	for name, value := range env {
+		if ListContainsElement(alwaysAllowedEnvVars, name) || ListContainsElement(allowedEnv, name) {
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+	}
+
+	startTime := time.Now()
+	err := WithContextError(ctx, cmd.Run())
+
+	ObserveCommand(startTime, workingDir, command, args, err)
+
+	if err != nil {
+		return &output, errors.New(ProcessExecutionError{
+			Err:        err,
+			Output:     output,
+			WorkingDir: workingDir,
+			Command:    command,
+			Args:       args,
+		})
+	}
+
+	return &output, nil
+}
+
+// RunCommandOutput runs the command in the given working directory and returns its stdout with the leading and
+// trailing whitespace trimmed. If the command fails, `ProcessExecutionError` with the command output is returned.
+func RunCommandOutput(ctx context.Context, workingDir string, command string, args ...string) (string, error) {
+	var output CmdOutput
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = workingDir
+	cmd.Stdout = &output.Stdout
+	cmd.Stderr = &output.Stderr
+
+	startTime := time.Now()
+	err := WithContextError(ctx, cmd.Run())
+
+	ObserveCommand(startTime, workingDir, command, args, err)
+
+	if err != nil {
+		return "", errors.New(ProcessExecutionError{
+			Err:        err,
+			Output:     output,
+			WorkingDir: workingDir,
+			Command:    command,
+			Args:       args,
+		})
+	}
+
+	return strings.TrimSpace(output.Stdout.String()), nil
+}
+
+// RunCommandWithStdin runs the command in the given working directory with the given reader piped to its stdin,
+// e.g. to format HCL read from stdin, and returns its captured output. If the command fails,
+// `ProcessExecutionError` with the command output is returned.
+func RunCommandWithStdin(ctx context.Context, workingDir string, stdin io.Reader, command string, args ...string) (*CmdOutput, error) {
+	var output CmdOutput
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = workingDir
+	cmd.Stdin = stdin
+	cmd.Stdout = &output.Stdout
+	cmd.Stderr = &output.Stderr
+
+	startTime := time.Now()
+	err := WithContextError(ctx, cmd.Run())
+
+	ObserveCommand(startTime, workingDir, command, args, err)
+
+	if err != nil {
+		return &output, errors.New(ProcessExecutionError{
+			Err:        err,
+			Output:     output,
+			WorkingDir: workingDir,
+			Command:    command,
+			Args:       args,
+		})
+	}
+
+	return &output, nil
+}
+
+// CommandKind is the classified outcome of a command, see `ClassifyCommandError`.
+type CommandKind int
+
+const (
+	// CommandKindSuccess means that the command exited with zero code.
+	CommandKindSuccess CommandKind = iota
+	// CommandKindNotFound means that the command binary is not found on PATH.
+	CommandKindNotFound
+	// CommandKindTimeout means that the command was killed because the deadline of its context was exceeded.
+	CommandKindTimeout
+	// CommandKindSignal means that the command was killed by a signal, including the cancellation of its context.
+	CommandKindSignal
+	// CommandKindExit means that the command exited with non-zero code, or could not be started.
+	CommandKindExit
+)
+
+// String implements `fmt.Stringer` interface.
+func (kind CommandKind) String() string {
+	switch kind {
+	case CommandKindSuccess:
+		return "success"
+	case CommandKindNotFound:
+		return "not found"
+	case CommandKindTimeout:
+		return "timeout"
+	case CommandKindSignal:
+		return "signal"
+	case CommandKindExit:
+		return "exit"
+	}
+
+	return "unknown"
+}
+
+// signaledExitCodeBase is added to the signal number to get the exit code of the commands killed by a signal,
+// the same as the shells do, e.g. 137 for SIGKILL.
+const signaledExitCodeBase = 128
+
+// ClassifyCommandError returns the kind and the exit code of the outcome of the command that returned the given error,
+// so callers can branch on why the command failed instead of interpreting the error themselves:
+//   - `CommandKindSuccess` and zero for nil;
+//   - `CommandKindNotFound` and -1 if the binary is missing;
+//   - `CommandKindTimeout` and `CanceledCommandExitCode` if the deadline of the context was exceeded;
+//   - `CommandKindSignal` and `CanceledCommandExitCode` if the context was canceled, or 128 plus the signal number
+//     if the command was killed by a signal;
+//   - `CommandKindExit` and the exit code returned by `GetExitCode`, -1 if the error has no exit code.
+func ClassifyCommandError(err error) (CommandKind, int) {
+	if err == nil {
+		return CommandKindSuccess, 0
+	}
+
+	if errors.Is(err, exec.ErrNotFound) {
+		return CommandKindNotFound, -1
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return CommandKindTimeout, CanceledCommandExitCode
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return CommandKindSignal, CanceledCommandExitCode
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return CommandKindSignal, signaledExitCodeBase + int(status.Signal())
+		}
+	}
+
+	exitCode, exitCodeErr := GetExitCode(err)
+	if exitCodeErr != nil {
+		return CommandKindExit, -1
+	}
+
+	return CommandKindExit, exitCode
+}
+
+// CommandRunResult is the result of `RunAndClassify`.
+type CommandRunResult struct {
+	// Err is the `ProcessExecutionError` returned by running the command, nil if the command succeeded.
+	Err error
+	// Output is the captured stdout and stderr of the command.
+	Output CmdOutput
+	// Kind is the classified outcome of the command.
+	Kind CommandKind
+	// ExitCode is the exit code of the command, see `ClassifyCommandError`.
+	ExitCode int
+}
+
+// RunAndClassify runs the command in the given working directory, the current directory is used if it's empty,
+// and returns its captured output along with the kind and the exit code of its outcome.
+func RunAndClassify(ctx context.Context, workingDir string, command string, args ...string) CommandRunResult {
+	var result CommandRunResult
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = workingDir
+	cmd.Stdout = &result.Output.Stdout
+	cmd.Stderr = &result.Output.Stderr
+
+	startTime := time.Now()
+	err := WithContextError(ctx, cmd.Run())
+
+	ObserveCommand(startTime, workingDir, command, args, err)
+
+	result.Kind, result.ExitCode = ClassifyCommandError(err)
+
+	if err != nil {
+		result.Err = errors.New(ProcessExecutionError{
+			Err:        err,
+			Output:     result.Output,
+			WorkingDir: workingDir,
+			Command:    command,
+			Args:       args,
+		})
+	}
+
+	return result
+}
+
+// CanceledCommandExitCode is the exit code of the commands killed because their context was canceled, e.g. by
+// the user interrupt or the deadline of `run-all`, the same code the shells use for the commands interrupted with SIGINT.
+const CanceledCommandExitCode = 130
+
+// WithContextError returns the error of the command run with the given context joined with the context error,
+// if the context is done, since the command was killed because of it rather than failed by itself.
+func WithContextError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	return errors.Join(ctx.Err(), err)
 }
 
 // GetExitCode returns the exit code of a command. If the error does not
 // implement errorCode or is not an exec.ExitError
 // or *errors.MultiError type, the error is returned.
+// The commands killed because of the canceled context return `CanceledCommandExitCode`.
 func GetExitCode(err error) (int, error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return CanceledCommandExitCode, nil
+	}
+
 	var exitStatus interface {
 		ExitStatus() (int, error)
 	}
@@ -73,6 +495,45 @@ func GetExitCode(err error) (int, error) {
 	return 0, err
 }
 
+// GetHighestExitCode returns the most severe exit code of the error, used to report the overall status of the commands
+// run for multiple units. Unlike `GetExitCode`, all the errors wrapped by *errors.MultiError, including the nested
+// ones, are scanned, regardless of their order, with the following precedence:
+//  1. the highest non-zero exit code;
+//  2. zero, if the exit codes of all the errors that have one are zero;
+//  3. the error itself, if none of the errors has an exit code.
+//
+// The errors of other types are handled the same as by `GetExitCode`.
+func GetHighestExitCode(err error) (int, error) {
+	var multiErr *errors.MultiError
+	if ok := errors.As(err, &multiErr); !ok {
+		return GetExitCode(err)
+	}
+
+	var (
+		highestExitCode int
+		hasExitCode     bool
+	)
+
+	for _, err := range multiErr.WrappedErrors() {
+		exitCode, exitCodeErr := GetHighestExitCode(err)
+		if exitCodeErr != nil {
+			continue
+		}
+
+		if !hasExitCode || exitCode > highestExitCode {
+			highestExitCode = exitCode
+		}
+
+		hasExitCode = true
+	}
+
+	if !hasExitCode {
+		return 0, err
+	}
+
+	return highestExitCode, nil
+}
+
 // ProcessExecutionError - error returned when a command fails, contains StdOut and StdErr
 type ProcessExecutionError struct {
 	Err        error
@@ -80,15 +541,36 @@ type ProcessExecutionError struct {
 	WorkingDir string
 	Command    string
 	Args       []string
+	// Signal is the signal sent to the command to interrupt it, nil if the command was not interrupted.
+	Signal os.Signal
 }
 
 func (err ProcessExecutionError) Error() string {
-	return fmt.Sprintf("Failed to execute \"%s %s\" in %s\n%s\n%v",
+	return err.message(err.Output.Stderr.String())
+}
+
+// message returns the error message with the given details of the failure, by default the stderr of the command.
+func (err ProcessExecutionError) message(details string) string {
+	msg := fmt.Sprintf("Failed to execute \"%s %s\" in %s",
 		err.Command,
-		strings.Join(err.Args, " "),
-		err.WorkingDir,
-		err.Output.Stderr.String(),
-		err.Err)
+		strings.Join(RedactArgs(err.Args), " "),
+		err.WorkingDir)
+
+	if err.Signal != nil {
+		msg = fmt.Sprintf("Execution of \"%s %s\" in %s was interrupted by the %s signal",
+			err.Command,
+			strings.Join(RedactArgs(err.Args), " "),
+			err.WorkingDir,
+			err.Signal)
+	}
+
+	msg = fmt.Sprintf("%s\n%s\n%v", msg, details, err.Err)
+
+	if err.Output.Truncated {
+		msg += "\nThe command output was truncated, since it exceeded the max output size."
+	}
+
+	return msg
 }
 
 func (err ProcessExecutionError) ExitStatus() (int, error) {
@@ -98,3 +580,100 @@ func (err ProcessExecutionError) ExitStatus() (int, error) {
 func (err ProcessExecutionError) Unwrap() error {
 	return err.Err
 }
+
+// redactedValue replaces the values of secret arguments.
+const redactedValue = redact.Mask
+
+// secretNameRegex matches the names of flags and variables that look like they hold secrets.
+var secretNameRegex = regexp.MustCompile(`(?i)(token|password|passwd|secret|api[-_]?key|access[-_]?key|private[-_]?key|credentials?)`) //nolint:gochecknoglobals
+
+var (
+	redactedFlagsMu sync.RWMutex //nolint:gochecknoglobals
+	// redactedFlags are the names of flags, without leading dashes, whose values are always redacted, including
+	// the values passed as the next argument.
+	redactedFlags = map[string]struct{}{ //nolint:gochecknoglobals
+		"auth":     {},
+		"pass":     {},
+		"pat":      {},
+		"bearer":   {},
+		"password": {},
+		"passwd":   {},
+		"token":    {},
+		"secret":   {},
+		"api-key":  {},
+	}
+)
+
+// AddRedactedFlags adds the names of flags whose values are redacted by `RedactArgs`, either `-flag=value` or
+// `-flag value`. The names are given without leading dashes.
+func AddRedactedFlags(names ...string) {
+	redactedFlagsMu.Lock()
+	defer redactedFlagsMu.Unlock()
+
+	for _, name := range names {
+		redactedFlags[strings.ToLower(strings.TrimLeft(name, "-"))] = struct{}{}
+	}
+}
+
+// RedactArgs returns a copy of the given command arguments with the values of secret flags masked, so they can be
+// safely logged. The values of the flags that look like they hold secrets are masked in the `-db-password=abc` form,
+// the `-token abc` form is supported only for the flags registered with `AddRedactedFlags`, so the boolean flags, such as
+// `--password-stdin`, don't mask the next argument. The `name=value` pairs whose name looks like a secret are masked too,
+// e.g. the value of `-var db_password=abc`. The values that look like secrets themselves, such as AWS access keys,
+// are masked regardless of their names.
+func RedactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	redactNext := false
+
+	for i, arg := range args {
+		if redactNext {
+			redacted[i] = redactedValue
+			redactNext = false
+
+			continue
+		}
+
+		redacted[i] = redact.Secrets(arg)
+		name, value, hasValue := strings.Cut(arg, "=")
+
+		if !strings.HasPrefix(arg, "-") {
+			if hasValue && isSecretName(name) {
+				redacted[i] = name + "=" + redactedValue
+			}
+
+			continue
+		}
+
+		if !isSecretName(strings.TrimLeft(name, "-")) {
+			// a secret passed as a flag value, e.g. -var=db_password=abc or -backend-config=access_key=abc
+			if key, _, found := strings.Cut(value, "="); found && isSecretName(key) {
+				redacted[i] = name + "=" + key + "=" + redactedValue
+			}
+
+			continue
+		}
+
+		if hasValue {
+			redacted[i] = name + "=" + redactedValue
+		} else {
+			redactNext = isRedactedFlag(strings.TrimLeft(name, "-"))
+		}
+	}
+
+	return redacted
+}
+
+// isSecretName returns true if the given flag or variable name is registered to be redacted or looks like a secret.
+func isSecretName(name string) bool {
+	return isRedactedFlag(name) || secretNameRegex.MatchString(name)
+}
+
+// isRedactedFlag returns true if the given flag name is registered to be redacted.
+func isRedactedFlag(name string) bool {
+	redactedFlagsMu.RLock()
+	defer redactedFlagsMu.RUnlock()
+
+	_, found := redactedFlags[strings.ToLower(name)]
+
+	return found
+}
@@ -0,0 +1,129 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package util_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommandWithAllowedEnv(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"PATH":    os.Getenv("PATH"),
+		"ALLOWED": "allowed",
+		"SECRET":  "secret",
+	}
+
+	output, err := util.RunCommandWithAllowedEnv(context.Background(), t.TempDir(), env, []string{"ALLOWED"}, "sh", "-c", `echo "$ALLOWED|$SECRET"`)
+	require.NoError(t, err)
+	assert.Equal(t, "allowed|\n", output.Stdout.String())
+
+	_, err = util.RunCommandWithAllowedEnv(context.Background(), t.TempDir(), env, nil, "sh", "-c", "echo failed >&2; exit 3")
+
+	var processErr util.ProcessExecutionError
+	require.ErrorAs(t, err, &processErr)
+	assert.Equal(t, "failed\n", processErr.Output.Stderr.String())
+
+	exitCode, err := util.GetExitCode(err)
+	require.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+}
+
+func TestRunCommandWithStdin(t *testing.T) {
+	t.Parallel()
+
+	output, err := util.RunCommandWithStdin(context.Background(), t.TempDir(), strings.NewReader("inputs = {}\n"), "cat")
+	require.NoError(t, err)
+	assert.Equal(t, "inputs = {}\n", output.Stdout.String())
+
+	_, err = util.RunCommandWithStdin(context.Background(), t.TempDir(), strings.NewReader("invalid"), "sh", "-c", "cat >&2; exit 2")
+
+	var processErr util.ProcessExecutionError
+	require.ErrorAs(t, err, &processErr)
+	assert.Equal(t, "invalid", processErr.Output.Stderr.String())
+
+	exitCode, err := util.GetExitCode(err)
+	require.NoError(t, err)
+	assert.Equal(t, 2, exitCode)
+}
+
+func TestIsCommandExecutableInDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tool.sh"), []byte("#!/bin/sh\nexit 0\n"), 0755))
+
+	assert.True(t, util.IsCommandExecutableInDir(context.Background(), dir, "./tool.sh"))
+	assert.False(t, util.IsCommandExecutableInDir(context.Background(), t.TempDir(), "./tool.sh"))
+}
+
+func TestRunCommandCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	startTime := time.Now()
+
+	_, err := util.RunCommandOutput(ctx, t.TempDir(), "sleep", "5")
+	require.Error(t, err)
+	assert.Less(t, time.Since(startTime), 5*time.Second)
+
+	var processErr util.ProcessExecutionError
+
+	require.ErrorAs(t, err, &processErr)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	exitCode, err := util.GetExitCode(err)
+	require.NoError(t, err)
+	assert.Equal(t, util.CanceledCommandExitCode, exitCode)
+
+	result := util.CommandExecutableStatus(ctx, "sleep", "5")
+	assert.Equal(t, util.CommandFailed, result.Status)
+	require.ErrorIs(t, result.Err, context.DeadlineExceeded)
+}
+
+func TestRunAndClassify(t *testing.T) {
+	t.Parallel()
+
+	result := util.RunAndClassify(context.Background(), t.TempDir(), "sh", "-c", "echo out; echo err >&2")
+	require.NoError(t, result.Err)
+	assert.Equal(t, util.CommandKindSuccess, result.Kind)
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Equal(t, "out\n", result.Output.Stdout.String())
+	assert.Equal(t, "err\n", result.Output.Stderr.String())
+
+	result = util.RunAndClassify(context.Background(), "", "sh", "-c", "echo failed >&2; exit 3")
+	assert.Equal(t, util.CommandKindExit, result.Kind)
+	assert.Equal(t, 3, result.ExitCode)
+	assert.Equal(t, "failed\n", result.Output.Stderr.String())
+
+	var processErr util.ProcessExecutionError
+	require.ErrorAs(t, result.Err, &processErr)
+
+	result = util.RunAndClassify(context.Background(), "", "terragrunt-not-existing-command")
+	assert.Equal(t, util.CommandKindNotFound, result.Kind)
+	assert.Equal(t, -1, result.ExitCode)
+
+	result = util.RunAndClassify(context.Background(), "", "sh", "-c", "kill -TERM $$")
+	assert.Equal(t, util.CommandKindSignal, result.Kind)
+	assert.Equal(t, 143, result.ExitCode)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	result = util.RunAndClassify(ctx, "", "sleep", "5")
+	assert.Equal(t, util.CommandKindTimeout, result.Kind)
+	assert.Equal(t, util.CanceledCommandExitCode, result.ExitCode)
+}
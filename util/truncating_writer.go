@@ -0,0 +1,71 @@
+package util
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// truncatedOutputMarkerFormat is written once the size limit of `TruncatingWriter` is exceeded.
+const truncatedOutputMarkerFormat = "\n[output truncated after %d bytes]\n"
+
+// TruncatingWriter writes up to the given number of bytes to the `writer`, the rest is discarded and replaced with
+// the `[output truncated after N bytes]` marker. The writes never fail because of the limit, so the command producing
+// the output keeps running. Used to prevent the command output buffered in memory from growing indefinitely.
+type TruncatingWriter struct {
+	writer    io.Writer
+	maxSize   int
+	written   int
+	truncated bool
+}
+
+// NewTruncatingWriter returns a new TruncatingWriter instance. If `maxSize` is not positive, the output is not truncated.
+func NewTruncatingWriter(writer io.Writer, maxSize int) *TruncatingWriter {
+	return &TruncatingWriter{
+		writer:  writer,
+		maxSize: maxSize,
+	}
+}
+
+// Write implements `io.Writer` interface.
+func (writer *TruncatingWriter) Write(p []byte) (int, error) {
+	if writer.maxSize <= 0 {
+		n, err := writer.writer.Write(p)
+		if err != nil {
+			return n, errors.New(err)
+		}
+
+		return n, nil
+	}
+
+	if writer.truncated {
+		return len(p), nil
+	}
+
+	data := p
+	if remaining := writer.maxSize - writer.written; len(data) > remaining {
+		data = data[:remaining]
+		writer.truncated = true
+	}
+
+	n, err := writer.writer.Write(data)
+	writer.written += n
+
+	if err != nil {
+		return n, errors.New(err)
+	}
+
+	if writer.truncated {
+		if _, err := fmt.Fprintf(writer.writer, truncatedOutputMarkerFormat, writer.maxSize); err != nil {
+			return n, errors.New(err)
+		}
+	}
+
+	return len(p), nil
+}
+
+// Truncated returns true if the output exceeded the size limit and was truncated.
+func (writer *TruncatingWriter) Truncated() bool {
+	return writer.truncated
+}
@@ -0,0 +1,48 @@
+package util_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncatingWriter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		maxSize           int
+		writes            []string
+		expectedOutput    string
+		expectedTruncated bool
+	}{
+		{"unlimited", 0, []string{"first ", "second"}, "first second", false},
+		{"within-limit", 12, []string{"first ", "second"}, "first second", false},
+		{"exceeds-limit", 8, []string{"first ", "second", " third"}, "first se\n[output truncated after 8 bytes]\n", true},
+		{"exceeds-limit-on-boundary", 6, []string{"first ", "second"}, "first \n[output truncated after 6 bytes]\n", true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			writer := util.NewTruncatingWriter(&buf, tc.maxSize)
+
+			for _, data := range tc.writes {
+				n, err := writer.Write([]byte(data))
+				require.NoError(t, err)
+				assert.Equal(t, len(data), n)
+			}
+
+			assert.Equal(t, tc.expectedOutput, buf.String())
+			assert.Equal(t, tc.expectedTruncated, writer.Truncated())
+		})
+	}
+}